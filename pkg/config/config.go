@@ -23,6 +23,8 @@ type StaticConfig struct {
 	DisableDestructive bool     `toml:"disable_destructive,omitempty"`
 	EnabledTools       []string `toml:"enabled_tools,omitempty"`
 	DisabledTools      []string `toml:"disabled_tools,omitempty"`
+	// Maximum number of items returned by the list tools in a single call (0 means no limit)
+	MaxListItems int `toml:"max_list_items,omitempty"`
 }
 
 type GroupVersionKind struct {
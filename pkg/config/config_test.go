@@ -56,6 +56,7 @@ kubeconfig = "test"
 list_output = "yaml"
 read_only = true
 disable_destructive = false
+max_list_items = 500
 
 denied_resources = [
     {group = "apps", version = "v1", kind = "Deployment"},
@@ -108,6 +109,9 @@ disabled_tools = ["pods_delete", "pods_top", "pods_log", "pods_run", "pods_exec"
 		if config.DisableDestructive {
 			t.Fatalf("Unexpected disable destructive: %v", config.DisableDestructive)
 		}
+		if config.MaxListItems != 500 {
+			t.Fatalf("Unexpected max_list_items value: %v", config.MaxListItems)
+		}
 		if len(config.EnabledTools) != 8 {
 			t.Fatalf("Unexpected enabled tools: %v", config.EnabledTools)
 		}
@@ -2,21 +2,36 @@ package helm
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"github.com/manusa/kubernetes-mcp-server/pkg/output"
 	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart"
 	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
 	"helm.sh/helm/v3/pkg/cli"
 	"helm.sh/helm/v3/pkg/registry"
 	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/releaseutil"
+	"helm.sh/helm/v3/pkg/storage/driver"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"log"
+	"maps"
+	"reflect"
+	"regexp"
 	"sigs.k8s.io/yaml"
+	"slices"
+	"strings"
 	"time"
 )
 
 type Kubernetes interface {
 	genericclioptions.RESTClientGetter
 	NamespaceOrDefault(namespace string) string
+	// CanShowSecrets returns an error if the values of the Secrets can't be revealed
+	CanShowSecrets() error
 }
 
 type Helm struct {
@@ -45,11 +60,10 @@ func (h *Helm) Install(ctx context.Context, chart string, values map[string]inte
 	install.Timeout = 5 * time.Minute
 	install.DryRun = false
 
-	chartRequested, err := install.ChartPathOptions.LocateChart(chart, cli.New())
+	chartLoaded, err := h.loadChart(&install.ChartPathOptions, chart)
 	if err != nil {
 		return "", err
 	}
-	chartLoaded, err := loader.Load(chartRequested)
 
 	installedRelease, err := install.RunWithContext(ctx, chartLoaded, values)
 	if err != nil {
@@ -62,6 +76,263 @@ func (h *Helm) Install(ctx context.Context, chart string, values map[string]inte
 	return string(ret), nil
 }
 
+type ValuesOptions struct {
+	// Values to pass to the chart, they take precedence over ValuesYaml
+	Values map[string]interface{}
+	// ValuesYaml is a YAML document with the values to pass to the chart
+	ValuesYaml string
+}
+
+// mergeValues returns the provided Values merged over the ones in ValuesYaml
+func (o ValuesOptions) mergeValues() (map[string]interface{}, error) {
+	values := map[string]interface{}{}
+	if o.ValuesYaml != "" {
+		if err := yaml.Unmarshal([]byte(o.ValuesYaml), &values); err != nil {
+			return nil, fmt.Errorf("invalid values YAML: %w", err)
+		}
+	}
+	return chartutil.MergeTables(o.Values, values), nil
+}
+
+type UpgradeOptions struct {
+	ValuesOptions
+	// Version of the chart to upgrade to (latest if empty)
+	Version string
+	// Install the release if it doesn't exist or was uninstalled (helm upgrade --install)
+	Install bool
+	// ReuseValues merges the values of the current release with the provided ones
+	ReuseValues bool
+	// ResetValues resets the values to the chart defaults before applying the provided ones
+	ResetValues bool
+}
+
+// Upgrade upgrades the release with the specified name to the provided chart (or installs it if options.Install is true and the release doesn't exist or was uninstalled)
+func (h *Helm) Upgrade(ctx context.Context, chart string, name string, namespace string, options UpgradeOptions) (string, error) {
+	currentRelease, upgradedRelease, err := h.upgrade(ctx, chart, name, namespace, options, false)
+	if err != nil {
+		return "", err
+	}
+	previousRevision := 0
+	if currentRelease != nil {
+		previousRevision = currentRelease.Version
+	}
+	simplified := simplify(upgradedRelease)
+	simplified[0]["previousRevision"] = previousRevision
+	ret, err := yaml.Marshal(simplified)
+	if err != nil {
+		return "", err
+	}
+	return string(ret), nil
+}
+
+type DiffOptions struct {
+	UpgradeOptions
+	// ShowSecrets disables the redaction of the Secret values in the diff, refused if the Secrets can't be revealed
+	ShowSecrets bool
+}
+
+// Diff returns the per-resource unified diff between the manifest of the release with the specified name and the manifest it would have
+// after being upgraded to the provided chart (rendered with a server-side dry-run)
+func (h *Helm) Diff(ctx context.Context, chart string, name string, namespace string, options DiffOptions) (string, error) {
+	if options.ShowSecrets {
+		if err := h.kubernetes.CanShowSecrets(); err != nil {
+			return "", fmt.Errorf("show secrets is not allowed: %w", err)
+		}
+	}
+	currentRelease, upgradedRelease, err := h.upgrade(ctx, chart, name, namespace, options.UpgradeOptions, true)
+	if err != nil {
+		return "", err
+	}
+	currentManifest, title := "", fmt.Sprintf("# Differences between the manifest of the non-existent release %s and the manifest of the release to install (server-side dry-run)\n", name)
+	if currentRelease != nil {
+		currentManifest = currentRelease.Manifest
+		title = fmt.Sprintf("# Differences between the manifest of the release %s (revision %d) and the manifest of the upgraded release (server-side dry-run)\n", name, currentRelease.Version)
+	}
+	diff, err := diffManifests(currentManifest, upgradedRelease.Manifest, options.ShowSecrets)
+	if err != nil {
+		return "", err
+	}
+	return title + diff, nil
+}
+
+// upgrade upgrades the release (or installs it if options.Install is true and the release doesn't exist or was uninstalled) and returns its current (nil if it didn't exist or was uninstalled) and upgraded states.
+// The current state is the deployed revision, or the last revision if none is deployed.
+// If dryRun is true, the upgraded release is rendered with a server-side dry-run and nothing is persisted.
+func (h *Helm) upgrade(ctx context.Context, chart string, name string, namespace string, options UpgradeOptions, dryRun bool) (*release.Release, *release.Release, error) {
+	if options.ReuseValues && options.ResetValues {
+		return nil, nil, fmt.Errorf("reuse_values and reset_values are mutually exclusive")
+	}
+	values, err := options.mergeValues()
+	if err != nil {
+		return nil, nil, err
+	}
+	cfg, err := h.newAction(h.kubernetes.NamespaceOrDefault(namespace), false)
+	if err != nil {
+		return nil, nil, err
+	}
+	currentRelease, err := cfg.Releases.Last(name)
+	if err != nil && !errors.Is(err, driver.ErrReleaseNotFound) {
+		return nil, nil, err
+	} else if err != nil && !options.Install {
+		return nil, nil, fmt.Errorf("release %s not found, set install to true to install it", name)
+	}
+	// A release uninstalled with its history kept is installed again, replacing its name (same as helm upgrade --install)
+	replace := currentRelease != nil && currentRelease.Info != nil && currentRelease.Info.Status == release.StatusUninstalled
+	if replace && !options.Install {
+		return nil, nil, fmt.Errorf("release %s is uninstalled, set install to true to install it", name)
+	} else if replace {
+		currentRelease = nil
+	}
+	// The upgrade replaces the deployed revision, the last one might be failed or pending (same as helm upgrade)
+	if currentRelease != nil {
+		deployedRelease, err := cfg.Releases.Deployed(name)
+		if err != nil && !errors.Is(err, driver.ErrNoDeployedReleases) {
+			return nil, nil, err
+		} else if err == nil {
+			currentRelease = deployedRelease
+		}
+	}
+	var upgradedRelease *release.Release
+	if currentRelease == nil {
+		install := action.NewInstall(cfg)
+		install.ReleaseName = name
+		install.Namespace = h.kubernetes.NamespaceOrDefault(namespace)
+		install.Version = options.Version
+		install.Replace = replace
+		install.Wait = !dryRun
+		install.Timeout = 5 * time.Minute
+		if dryRun {
+			install.DryRun = true
+			install.DryRunOption = "server"
+		}
+		chartLoaded, err := h.loadChart(&install.ChartPathOptions, chart)
+		if err != nil {
+			return nil, nil, err
+		}
+		if upgradedRelease, err = install.RunWithContext(ctx, chartLoaded, values); err != nil {
+			return nil, nil, err
+		}
+	} else {
+		upgrade := action.NewUpgrade(cfg)
+		upgrade.Namespace = h.kubernetes.NamespaceOrDefault(namespace)
+		upgrade.Version = options.Version
+		upgrade.Install = options.Install
+		upgrade.ReuseValues = options.ReuseValues
+		upgrade.ResetValues = options.ResetValues
+		upgrade.Wait = !dryRun
+		upgrade.Timeout = 5 * time.Minute
+		if dryRun {
+			upgrade.DryRun = true
+			upgrade.DryRunOption = "server"
+		}
+		chartLoaded, err := h.loadChart(&upgrade.ChartPathOptions, chart)
+		if err != nil {
+			return nil, nil, err
+		}
+		if upgradedRelease, err = upgrade.RunWithContext(ctx, name, chartLoaded, values); err != nil {
+			return nil, nil, err
+		}
+	}
+	return currentRelease, upgradedRelease, nil
+}
+
+type TemplateOptions struct {
+	ValuesOptions
+	// Version of the chart to render (latest if empty)
+	Version string
+	// IncludeCRDs includes the CRDs of the chart in the rendered manifest
+	IncludeCRDs bool
+	// KubeVersion used for Capabilities.KubeVersion (default Helm version if empty)
+	KubeVersion string
+}
+
+// Template renders the provided chart locally, without contacting the cluster (helm template)
+func (h *Helm) Template(ctx context.Context, chart string, name string, namespace string, options TemplateOptions) (string, error) {
+	values, err := options.mergeValues()
+	if err != nil {
+		return "", err
+	}
+	cfg, err := h.newClientOnlyAction()
+	if err != nil {
+		return "", err
+	}
+	install := action.NewInstall(cfg)
+	install.ReleaseName = name
+	if install.ReleaseName == "" {
+		install.ReleaseName = "release-name"
+	}
+	install.Namespace = h.kubernetes.NamespaceOrDefault(namespace)
+	install.Version = options.Version
+	install.IncludeCRDs = options.IncludeCRDs
+	install.ClientOnly = true
+	install.DryRun = true
+	install.Replace = true // Skip the name check
+	if options.KubeVersion != "" {
+		if install.KubeVersion, err = chartutil.ParseKubeVersion(options.KubeVersion); err != nil {
+			return "", fmt.Errorf("invalid kube version %s: %w", options.KubeVersion, err)
+		}
+	}
+	chartLoaded, err := h.loadChart(&install.ChartPathOptions, chart)
+	if err != nil {
+		return "", err
+	}
+	renderedRelease, err := install.RunWithContext(ctx, chartLoaded, values)
+	if err != nil {
+		return "", err
+	}
+	ret := strings.TrimSpace(renderedRelease.Manifest) + "\n"
+	for _, hook := range renderedRelease.Hooks {
+		ret += fmt.Sprintf("---\n# Source: %s\n%s\n", hook.Path, hook.Manifest)
+	}
+	return ret, nil
+}
+
+type LintOptions struct {
+	ValuesOptions
+	// Version of the chart to lint (latest if empty)
+	Version string
+	// Strict fails on lint warnings
+	Strict bool
+}
+
+// Lint examines the provided chart for possible issues (helm lint)
+func (h *Helm) Lint(chart string, namespace string, options LintOptions) (string, error) {
+	values, err := options.mergeValues()
+	if err != nil {
+		return "", err
+	}
+	cfg, err := h.newClientOnlyAction()
+	if err != nil {
+		return "", err
+	}
+	// Install provides the ChartPathOptions initialized with the registry client
+	chartPathOptions := action.NewInstall(cfg).ChartPathOptions
+	chartPathOptions.Version = options.Version
+	chartPath, err := chartPathOptions.LocateChart(chart, cli.New())
+	if err != nil {
+		return "", err
+	}
+	lint := action.NewLint()
+	lint.Namespace = h.kubernetes.NamespaceOrDefault(namespace)
+	lint.Strict = options.Strict
+	result := lint.Run([]string{chartPath}, values)
+	ret := fmt.Sprintf("==> Linting %s\n", chart)
+	// The errors of the linted charts are included in the messages
+	if len(result.Messages) == 0 {
+		for _, err := range result.Errors {
+			ret += fmt.Sprintf("Error %s\n", err)
+		}
+	}
+	for _, msg := range result.Messages {
+		ret += msg.Error() + "\n"
+	}
+	failed := 0
+	if len(result.Errors) > 0 {
+		failed = 1
+	}
+	return ret + fmt.Sprintf("\n1 chart(s) linted, %d chart(s) failed", failed), nil
+}
+
 // List lists all the releases for the specified namespace (or current namespace if). Or allNamespaces is true, it lists all releases across all namespaces.
 func (h *Helm) List(namespace string, allNamespaces bool) (string, error) {
 	cfg, err := h.newAction(namespace, allNamespaces)
@@ -101,6 +372,185 @@ func (h *Helm) Uninstall(name string, namespace string) (string, error) {
 	return fmt.Sprintf("Uninstalled release %s %s", uninstalledRelease.Release.Name, uninstalledRelease.Info), nil
 }
 
+// GetModes are the supported modes of Helm.Get
+var GetModes = []string{"values", "manifest", "notes", "hooks"}
+
+type GetOptions struct {
+	// Revision of the release (latest if 0)
+	Revision int
+	// AllValues returns the computed values (chart defaults merged with the user-supplied ones) instead of the user-supplied ones (values mode only)
+	AllValues bool
+	// ShowSecrets disables the redaction of the Secret values in the manifests (manifest and hooks modes only), refused if
+	// the Secrets can't be revealed
+	ShowSecrets bool
+}
+
+// Get returns the values, manifest, notes, or hooks (depending on mode) of the release with the specified name
+func (h *Helm) Get(name string, namespace string, mode string, options GetOptions) (string, error) {
+	if !slices.Contains(GetModes, mode) {
+		return "", fmt.Errorf("unsupported mode %s, supported modes are %s", mode, strings.Join(GetModes, ", "))
+	}
+	if options.ShowSecrets {
+		if err := h.kubernetes.CanShowSecrets(); err != nil {
+			return "", fmt.Errorf("show secrets is not allowed: %w", err)
+		}
+	}
+	cfg, err := h.newAction(h.kubernetes.NamespaceOrDefault(namespace), false)
+	if err != nil {
+		return "", err
+	}
+	if mode == "values" {
+		getValues := action.NewGetValues(cfg)
+		getValues.Version = options.Revision
+		getValues.AllValues = options.AllValues
+		values, err := getValues.Run(name)
+		if err != nil {
+			return "", err
+		} else if len(values) == 0 {
+			return fmt.Sprintf("Release %s has no user-supplied values", name), nil
+		}
+		ret, err := yaml.Marshal(values)
+		if err != nil {
+			return "", err
+		}
+		return string(ret), nil
+	}
+	get := action.NewGet(cfg)
+	get.Version = options.Revision
+	getRelease, err := get.Run(name)
+	if err != nil {
+		return "", err
+	}
+	var ret string
+	switch mode {
+	case "manifest":
+		ret = getRelease.Manifest
+	case "notes":
+		if getRelease.Info != nil {
+			ret = getRelease.Info.Notes
+		}
+	case "hooks":
+		for _, hook := range getRelease.Hooks {
+			ret += fmt.Sprintf("---\n# Source: %s\n%s\n", hook.Path, hook.Manifest)
+		}
+	}
+	if strings.TrimSpace(ret) == "" {
+		return fmt.Sprintf("Release %s has no %s", name, mode), nil
+	}
+	if mode != "notes" && !options.ShowSecrets {
+		ret = redactSecrets(ret)
+	}
+	return ret, nil
+}
+
+// History lists the revisions of the release with the specified name, up to max revisions (all of them if max is 0), the most recent last
+func (h *Helm) History(name string, namespace string, max int) (string, error) {
+	cfg, err := h.newAction(h.kubernetes.NamespaceOrDefault(namespace), false)
+	if err != nil {
+		return "", err
+	}
+	history := action.NewHistory(cfg)
+	releases, err := history.Run(name)
+	if errors.Is(err, driver.ErrReleaseNotFound) {
+		return fmt.Sprintf("Release %s not found", name), nil
+	} else if err != nil {
+		return "", err
+	}
+	releaseutil.SortByRevision(releases)
+	if max > 0 && len(releases) > max {
+		releases = releases[len(releases)-max:]
+	}
+	revisions := simplify(releases...)
+	for i, r := range releases {
+		if r.Info != nil {
+			revisions[i]["description"] = r.Info.Description
+		}
+	}
+	ret, err := yaml.Marshal(revisions)
+	if err != nil {
+		return "", err
+	}
+	return string(ret), nil
+}
+
+// Rollback rolls back the release with the specified name to the provided revision (or to the previous one if revision is 0)
+func (h *Helm) Rollback(name string, namespace string, revision int, wait bool, timeout time.Duration) (string, error) {
+	cfg, err := h.newAction(h.kubernetes.NamespaceOrDefault(namespace), false)
+	if err != nil {
+		return "", err
+	}
+	currentRelease, err := cfg.Releases.Last(name)
+	if err != nil {
+		return "", err
+	}
+	rollback := action.NewRollback(cfg)
+	rollback.Version = revision
+	rollback.Wait = wait
+	rollback.Timeout = timeout
+	if err = rollback.Run(name); err != nil {
+		return "", err
+	}
+	rolledBackRelease, err := cfg.Releases.Last(name)
+	if err != nil {
+		return "", err
+	}
+	simplified := simplify(rolledBackRelease)
+	simplified[0]["previousRevision"] = currentRelease.Version
+	if rolledBackRelease.Info != nil {
+		simplified[0]["description"] = rolledBackRelease.Info.Description
+	}
+	ret, err := yaml.Marshal(simplified)
+	if err != nil {
+		return "", err
+	}
+	return string(ret), nil
+}
+
+// Status returns the information, notes, and the status of the resources of the release with the specified name (and revision, latest if 0)
+func (h *Helm) Status(name string, namespace string, revision int) (string, error) {
+	cfg, err := h.newAction(h.kubernetes.NamespaceOrDefault(namespace), false)
+	if err != nil {
+		return "", err
+	}
+	status := action.NewStatus(cfg)
+	status.Version = revision
+	status.ShowResources = true
+	statusRelease, err := status.Run(name)
+	if err != nil {
+		return "", err
+	}
+	ret := simplify(statusRelease)[0]
+	if statusRelease.Info != nil {
+		ret["description"] = statusRelease.Info.Description
+		if statusRelease.Info.Notes != "" {
+			ret["notes"] = statusRelease.Info.Notes
+		}
+		ret["resources"] = simplifyResources(statusRelease.Info.Resources)
+	}
+	marshalled, err := yaml.Marshal(ret)
+	if err != nil {
+		return "", err
+	}
+	return string(marshalled), nil
+}
+
+func (h *Helm) loadChart(chartPathOptions *action.ChartPathOptions, chartReference string) (*chart.Chart, error) {
+	chartRequested, err := chartPathOptions.LocateChart(chartReference, cli.New())
+	if err != nil {
+		return nil, err
+	}
+	return loader.Load(chartRequested)
+}
+
+// newClientOnlyAction returns a configuration for the actions that don't contact the cluster
+func (h *Helm) newClientOnlyAction() (*action.Configuration, error) {
+	registryClient, err := registry.NewClient()
+	if err != nil {
+		return nil, err
+	}
+	return &action.Configuration{RegistryClient: registryClient, Log: log.Printf}, nil
+}
+
 func (h *Helm) newAction(namespace string, allNamespaces bool) (*action.Configuration, error) {
 	cfg := new(action.Configuration)
 	applicableNamespace := ""
@@ -137,3 +587,180 @@ func simplify(release ...*release.Release) []map[string]interface{} {
 	}
 	return ret
 }
+
+var manifestSeparator = regexp.MustCompile(`(?m)^---[ \t]*$\n?`)
+
+// redactSecrets replaces the data and stringData values of the Secrets in the provided multi-document manifest
+func redactSecrets(manifest string) string {
+	documents := manifestSeparator.Split(manifest, -1)
+	for i, document := range documents {
+		obj := map[string]interface{}{}
+		if err := yaml.Unmarshal([]byte(document), &obj); err != nil || obj["kind"] != "Secret" {
+			continue
+		}
+		for _, field := range []string{"data", "stringData"} {
+			if values, ok := obj[field].(map[string]interface{}); ok {
+				for key := range values {
+					values[key] = "REDACTED"
+				}
+			}
+		}
+		redacted, err := yaml.Marshal(obj)
+		if err != nil {
+			redacted = []byte("# Secret redacted\n")
+		}
+		// Preserve the leading comments (e.g. # Source: chart/templates/secret.yaml)
+		comments := ""
+		for _, line := range strings.SplitAfter(document, "\n") {
+			if !strings.HasPrefix(line, "#") {
+				break
+			}
+			comments += line
+		}
+		documents[i] = comments + string(redacted)
+	}
+	return strings.Join(documents, "---\n")
+}
+
+// diffManifests returns the unified diff of each resource in the provided multi-document manifests, reporting whether it was added, removed, changed, or not changed.
+// Unless showSecrets is true, the values of the Secrets are redacted (changed values are still reported).
+func diffManifests(currentManifest, targetManifest string, showSecrets bool) (string, error) {
+	current, err := parseManifest(currentManifest)
+	if err != nil {
+		return "", fmt.Errorf("invalid current manifest: %w", err)
+	}
+	target, err := parseManifest(targetManifest)
+	if err != nil {
+		return "", fmt.Errorf("invalid target manifest: %w", err)
+	}
+	ids := slices.Collect(maps.Keys(current))
+	for id := range target {
+		if _, ok := current[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+	ret := ""
+	added, removed, changed := 0, 0, 0
+	for _, id := range ids {
+		currentObj, targetObj := current[id], target[id]
+		if !showSecrets {
+			redactSecretValues(currentObj, targetObj)
+		}
+		currentYaml, targetYaml := "", ""
+		if currentObj != nil {
+			marshalled, err := yaml.Marshal(currentObj)
+			if err != nil {
+				return "", err
+			}
+			currentYaml = string(marshalled)
+		}
+		if targetObj != nil {
+			marshalled, err := yaml.Marshal(targetObj)
+			if err != nil {
+				return "", err
+			}
+			targetYaml = string(marshalled)
+		}
+		unifiedDiff, err := output.UnifiedDiff("current/"+id, "target/"+id, currentYaml, targetYaml)
+		if err != nil {
+			return "", err
+		}
+		switch {
+		case currentObj == nil:
+			added++
+			ret += "## " + id + " (added)\n" + unifiedDiff
+		case targetObj == nil:
+			removed++
+			ret += "## " + id + " (removed)\n" + unifiedDiff
+		case unifiedDiff == "":
+			ret += "## " + id + " (no changes)\n"
+		default:
+			changed++
+			ret += "## " + id + " (changed)\n" + unifiedDiff
+		}
+	}
+	return fmt.Sprintf("# %d added, %d removed, %d changed\n", added, removed, changed) + ret, nil
+}
+
+// parseManifest parses the resources of the provided multi-document manifest, keyed by their apiVersion/kind/[namespace/]name,
+// duplicate resources are reported as an error
+func parseManifest(manifest string) (map[string]map[string]interface{}, error) {
+	ret := make(map[string]map[string]interface{})
+	for _, document := range manifestSeparator.Split(manifest, -1) {
+		obj := map[string]interface{}{}
+		if err := yaml.Unmarshal([]byte(document), &obj); err != nil {
+			return nil, err
+		} else if len(obj) == 0 {
+			continue
+		}
+		resource := &unstructured.Unstructured{Object: obj}
+		id := resource.GetAPIVersion() + "/" + resource.GetKind() + "/"
+		if resource.GetNamespace() != "" {
+			id += resource.GetNamespace() + "/"
+		}
+		id += resource.GetName()
+		if _, duplicate := ret[id]; duplicate {
+			return nil, fmt.Errorf("duplicate resource %s in manifest", id)
+		}
+		ret[id] = obj
+	}
+	return ret, nil
+}
+
+// redactSecretValues replaces the data and stringData values of the provided current and target Secrets (either might be nil),
+// the target values that differ from the current ones are marked as changed
+func redactSecretValues(current, target map[string]interface{}) {
+	for _, field := range []string{"data", "stringData"} {
+		var currentValues, targetValues map[string]interface{}
+		if current != nil && current["kind"] == "Secret" {
+			currentValues, _ = current[field].(map[string]interface{})
+		}
+		if target != nil && target["kind"] == "Secret" {
+			targetValues, _ = target[field].(map[string]interface{})
+		}
+		for key, value := range targetValues {
+			if currentValue, ok := currentValues[key]; ok && !reflect.DeepEqual(currentValue, value) {
+				targetValues[key] = "REDACTED (changed)"
+			} else {
+				targetValues[key] = "REDACTED"
+			}
+		}
+		for key := range currentValues {
+			currentValues[key] = "REDACTED"
+		}
+	}
+}
+
+// simplifyResources returns the identifiers and the status of the release resources sorted by their kind
+func simplifyResources(resources map[string][]runtime.Object) []map[string]interface{} {
+	ret := make([]map[string]interface{}, 0)
+	for _, key := range slices.Sorted(maps.Keys(resources)) {
+		for _, obj := range resources[key] {
+			u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+			if err != nil {
+				continue
+			}
+			resource := &unstructured.Unstructured{Object: u}
+			simplified := map[string]interface{}{
+				"kind": resource.GetKind(),
+				"name": resource.GetName(),
+			}
+			if resource.GetAPIVersion() != "" {
+				simplified["apiVersion"] = resource.GetAPIVersion()
+			}
+			if resource.GetKind() == "" {
+				// Related Pods might be retrieved without TypeMeta, the key is in the form version/Kind (or version/Kind(related))
+				simplified["kind"] = strings.TrimSuffix(key[strings.LastIndex(key, "/")+1:], "(related)")
+			}
+			if resource.GetNamespace() != "" {
+				simplified["namespace"] = resource.GetNamespace()
+			}
+			if status, ok := resource.Object["status"]; ok {
+				simplified["status"] = status
+			}
+			ret = append(ret, simplified)
+		}
+	}
+	return ret
+}
@@ -53,6 +53,7 @@ type MCPServerOptions struct {
 	ListOutput         string
 	ReadOnly           bool
 	DisableDestructive bool
+	MaxListItems       int
 
 	ConfigPath   string
 	StaticConfig *config.StaticConfig
@@ -102,6 +103,7 @@ func NewMCPServer(streams genericiooptions.IOStreams) *cobra.Command {
 	cmd.Flags().StringVar(&o.ListOutput, "list-output", o.ListOutput, "Output format for resource list operations (one of: "+strings.Join(output.Names, ", ")+"). Defaults to table.")
 	cmd.Flags().BoolVar(&o.ReadOnly, "read-only", o.ReadOnly, "If true, only tools annotated with readOnlyHint=true are exposed")
 	cmd.Flags().BoolVar(&o.DisableDestructive, "disable-destructive", o.DisableDestructive, "If true, tools annotated with destructiveHint=true are disabled")
+	cmd.Flags().IntVar(&o.MaxListItems, "max-list-items", o.MaxListItems, "Maximum number of items returned by the list tools in a single call (0 means no limit)")
 
 	return cmd
 }
@@ -147,6 +149,9 @@ func (m *MCPServerOptions) loadFlags(cmd *cobra.Command) {
 	if cmd.Flag("disable-destructive").Changed {
 		m.StaticConfig.DisableDestructive = m.DisableDestructive
 	}
+	if cmd.Flag("max-list-items").Changed {
+		m.StaticConfig.MaxListItems = m.MaxListItems
+	}
 }
 
 func (m *MCPServerOptions) initializeLogging() {
@@ -180,6 +185,7 @@ func (m *MCPServerOptions) Run() error {
 	klog.V(1).Infof(" - ListOutput: %s", listOutput.GetName())
 	klog.V(1).Infof(" - Read-only mode: %t", m.StaticConfig.ReadOnly)
 	klog.V(1).Infof(" - Disable destructive tools: %t", m.StaticConfig.DisableDestructive)
+	klog.V(1).Infof(" - Max list items: %d", m.StaticConfig.MaxListItems)
 
 	if m.Version {
 		_, _ = fmt.Fprintf(m.Out, "%s\n", version.Version)
@@ -230,3 +230,24 @@ func TestDisableDestructive(t *testing.T) {
 		}
 	})
 }
+
+func TestMaxListItems(t *testing.T) {
+	t.Run("defaults to 0", func(t *testing.T) {
+		ioStreams, out := testStream()
+		rootCmd := NewMCPServer(ioStreams)
+		rootCmd.SetArgs([]string{"--version", "--log-level=1"})
+		if err := rootCmd.Execute(); !strings.Contains(out.String(), " - Max list items: 0") {
+			t.Fatalf("Expected max list items 0, got %s %v", out, err)
+		}
+	})
+	t.Run("set with --max-list-items", func(t *testing.T) {
+		ioStreams, out := testStream()
+		rootCmd := NewMCPServer(ioStreams)
+		rootCmd.SetArgs([]string{"--version", "--log-level=1", "--max-list-items=100"})
+		_ = rootCmd.Execute()
+		expected := `(?m)\" - Max list items\: 100\"`
+		if m, err := regexp.MatchString(expected, out.String()); !m || err != nil {
+			t.Fatalf("Expected max list items to be %s, got %s %v", expected, out.String(), err)
+		}
+	})
+}
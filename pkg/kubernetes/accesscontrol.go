@@ -1,6 +1,7 @@
 package kubernetes
 
 import (
+	"errors"
 	"fmt"
 
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -38,3 +39,16 @@ func isAllowed(
 func isNotAllowedError(gvk *schema.GroupVersionKind) error {
 	return fmt.Errorf("resource not allowed: %s", gvk.String())
 }
+
+// CanShowSecrets returns an error if the values of the Secrets can't be revealed (e.g. by the Helm manifests), they're
+// only shown when Secrets are allowed and the server is not read-only
+func (m *Manager) CanShowSecrets() error {
+	gvk := &schema.GroupVersionKind{Group: "", Version: "v1", Kind: "Secret"}
+	if !isAllowed(m.staticConfig, gvk) {
+		return isNotAllowedError(gvk)
+	}
+	if m.staticConfig != nil && m.staticConfig.ReadOnly {
+		return errors.New("the values of the Secrets can't be shown in read-only mode")
+	}
+	return nil
+}
@@ -3,18 +3,27 @@ package kubernetes
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
 
+	appsv1 "k8s.io/api/apps/v1"
 	authorizationv1api "k8s.io/api/authorization/v1"
 	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/httpstream"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/kubernetes"
+	appsv1client "k8s.io/client-go/kubernetes/typed/apps/v1"
 	authorizationv1 "k8s.io/client-go/kubernetes/typed/authorization/v1"
 	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/portforward"
 	"k8s.io/client-go/tools/remotecommand"
+	"k8s.io/client-go/transport/spdy"
 	"k8s.io/metrics/pkg/apis/metrics"
 	metricsv1beta1api "k8s.io/metrics/pkg/apis/metrics/v1beta1"
 	metricsv1beta1 "k8s.io/metrics/pkg/client/clientset/versioned/typed/metrics/v1beta1"
@@ -28,6 +37,7 @@ import (
 type AccessControlClientset struct {
 	cfg             *rest.Config
 	delegate        kubernetes.Interface
+	restricted      kubernetes.Interface
 	discoveryClient discovery.DiscoveryInterface
 	metricsV1beta1  *metricsv1beta1.MetricsV1beta1Client
 	staticConfig    *config.StaticConfig // TODO: maybe just store the denied resource slice
@@ -37,6 +47,91 @@ func (a *AccessControlClientset) DiscoveryClient() discovery.DiscoveryInterface
 	return a.discoveryClient
 }
 
+func (a *AccessControlClientset) Nodes() (corev1.NodeInterface, error) {
+	gvk := &schema.GroupVersionKind{Group: "", Version: "v1", Kind: "Node"}
+	if !isAllowed(a.staticConfig, gvk) {
+		return nil, isNotAllowedError(gvk)
+	}
+	return a.delegate.CoreV1().Nodes(), nil
+}
+
+// NodesCordon returns the restricted clientset used by the kubectl cordon helper to mark Nodes as (un)schedulable,
+// access is checked for Nodes
+func (a *AccessControlClientset) NodesCordon() (kubernetes.Interface, error) {
+	return a.restrictedIfAllowed(&schema.GroupVersionKind{Group: "", Version: "v1", Kind: "Node"})
+}
+
+// NodesDescribe returns the restricted clientset used by the kubectl node describer, access is checked for Nodes and for
+// the Pods scheduled on them
+func (a *AccessControlClientset) NodesDescribe() (kubernetes.Interface, error) {
+	return a.restrictedIfAllowed(
+		&schema.GroupVersionKind{Group: "", Version: "v1", Kind: "Node"},
+		&schema.GroupVersionKind{Group: "", Version: "v1", Kind: "Pod"},
+	)
+}
+
+// NodesDrain returns the restricted clientset used by the kubectl drain helpers to cordon the Node and evict its Pods,
+// access is checked for Nodes, Pods, and for Evictions
+func (a *AccessControlClientset) NodesDrain() (kubernetes.Interface, error) {
+	return a.restrictedIfAllowed(
+		&schema.GroupVersionKind{Group: "", Version: "v1", Kind: "Node"},
+		&schema.GroupVersionKind{Group: "", Version: "v1", Kind: "Pod"},
+		&schema.GroupVersionKind{Group: policyv1.GroupName, Version: policyv1.SchemeGroupVersion.Version, Kind: "Eviction"},
+	)
+}
+
+// NodesProxy returns the request to the path of the Node kubelet API through the API server proxy subresource,
+// the name and path can't escape the Node proxy subresource (e.g. with / or .. segments)
+func (a *AccessControlClientset) NodesProxy(name, path string) (*rest.Request, error) {
+	gvk := &schema.GroupVersionKind{Group: "", Version: "v1", Kind: "Node"}
+	if !isAllowed(a.staticConfig, gvk) {
+		return nil, isNotAllowedError(gvk)
+	}
+	if name == "" || strings.Contains(name, "/") {
+		return nil, fmt.Errorf("invalid node name %q", name)
+	}
+	if slices.Contains(strings.Split(path, "/"), "..") {
+		return nil, fmt.Errorf("invalid node proxy path %q", path)
+	}
+	client := a.delegate.CoreV1().RESTClient()
+	req := client.Get().Resource("nodes").Name(name).SubResource("proxy").Suffix(path)
+	if err := req.Error(); err != nil {
+		return nil, err
+	}
+	// Suffix trims the trailing slash required by the kubelet for directories (e.g. /logs/), AbsPath preserves it
+	if strings.HasSuffix(path, "/") {
+		hostURL, _, err := rest.DefaultServerUrlFor(a.cfg)
+		if err != nil {
+			return nil, err
+		}
+		req = client.Get().AbsPath(strings.TrimPrefix(req.URL().Path, strings.TrimSuffix(hostURL.Path, "/")) + "/")
+	}
+	return req, nil
+}
+
+func (a *AccessControlClientset) NodesMetricses(ctx context.Context, name string, listOptions metav1.ListOptions) (*metrics.NodeMetricsList, error) {
+	gvk := &schema.GroupVersionKind{Group: metrics.GroupName, Version: metricsv1beta1api.SchemeGroupVersion.Version, Kind: "NodeMetrics"}
+	if !isAllowed(a.staticConfig, gvk) {
+		return nil, isNotAllowedError(gvk)
+	}
+	versionedMetrics := &metricsv1beta1api.NodeMetricsList{}
+	var err error
+	if name != "" {
+		m, err := a.metricsV1beta1.NodeMetricses().Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get metrics for node %s: %w", name, err)
+		}
+		versionedMetrics.Items = []metricsv1beta1api.NodeMetrics{*m}
+	} else {
+		versionedMetrics, err = a.metricsV1beta1.NodeMetricses().List(ctx, listOptions)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list node metrics: %w", err)
+		}
+	}
+	convertedMetrics := &metrics.NodeMetricsList{}
+	return convertedMetrics, metricsv1beta1api.Convert_v1beta1_NodeMetricsList_To_metrics_NodeMetricsList(versionedMetrics, convertedMetrics, nil)
+}
+
 func (a *AccessControlClientset) Pods(namespace string) (corev1.PodInterface, error) {
 	gvk := &schema.GroupVersionKind{Group: "", Version: "v1", Kind: "Pod"}
 	if !isAllowed(a.staticConfig, gvk) {
@@ -72,6 +167,39 @@ func (a *AccessControlClientset) PodsExec(namespace, name string, podExecOptions
 	})
 }
 
+// PodsPortForward returns the dialer to forward ports of the Pod, the WebSocket tunneling protocol is attempted first
+// with a fallback to SPDY (same as kubectl port-forward)
+func (a *AccessControlClientset) PodsPortForward(namespace, name string) (httpstream.Dialer, error) {
+	gvk := &schema.GroupVersionKind{Group: "", Version: "v1", Kind: "Pod"}
+	if !isAllowed(a.staticConfig, gvk) {
+		return nil, isNotAllowedError(gvk)
+	}
+	portForwardURL := a.delegate.CoreV1().RESTClient().
+		Post().
+		Resource("pods").
+		Namespace(namespace).
+		Name(name).
+		SubResource("portforward").
+		URL()
+	transport, upgrader, err := spdy.RoundTripperFor(a.cfg)
+	if err != nil {
+		return nil, err
+	}
+	spdyDialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, "POST", portForwardURL)
+	tunnelingDialer, err := portforward.NewSPDYOverWebsocketDialer(portForwardURL, a.cfg)
+	if err != nil {
+		return nil, err
+	}
+	return portforward.NewFallbackDialer(tunnelingDialer, spdyDialer, func(err error) bool {
+		return httpstream.IsUpgradeFailure(err) || httpstream.IsHTTPSProxyError(err)
+	}), nil
+}
+
+// PodsProxy returns the URL of the path of the Pod port through the API server proxy subresource and the client to request it
+func (a *AccessControlClientset) PodsProxy(namespace, name, scheme, port, path string) (*http.Client, *url.URL, error) {
+	return a.proxy(&schema.GroupVersionKind{Group: "", Version: "v1", Kind: "Pod"}, "pods", namespace, name, scheme, port, path)
+}
+
 func (a *AccessControlClientset) PodsMetricses(ctx context.Context, namespace, name string, listOptions metav1.ListOptions) (*metrics.PodMetricsList, error) {
 	gvk := &schema.GroupVersionKind{Group: metrics.GroupName, Version: metricsv1beta1api.SchemeGroupVersion.Version, Kind: "PodMetrics"}
 	if !isAllowed(a.staticConfig, gvk) {
@@ -103,6 +231,67 @@ func (a *AccessControlClientset) Services(namespace string) (corev1.ServiceInter
 	return a.delegate.CoreV1().Services(namespace), nil
 }
 
+// ServicesProxy returns the URL of the path of the Service port through the API server proxy subresource and the client to request it
+func (a *AccessControlClientset) ServicesProxy(namespace, name, scheme, port, path string) (*http.Client, *url.URL, error) {
+	return a.proxy(&schema.GroupVersionKind{Group: "", Version: "v1", Kind: "Service"}, "services", namespace, name, scheme, port, path)
+}
+
+func (a *AccessControlClientset) proxy(gvk *schema.GroupVersionKind, resource, namespace, name, scheme, port, path string) (*http.Client, *url.URL, error) {
+	if !isAllowed(a.staticConfig, gvk) {
+		return nil, nil, isNotAllowedError(gvk)
+	}
+	target, err := url.Parse(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	// https://kubernetes.io/docs/tasks/access-application-cluster/access-cluster-services/#manually-constructing-apiserver-proxy-urls
+	proxyURL := a.delegate.CoreV1().RESTClient().
+		Get().
+		Resource(resource).
+		Namespace(namespace).
+		Name(scheme + ":" + name + ":" + port).
+		SubResource("proxy").
+		Suffix(target.Path).
+		URL()
+	proxyURL.RawQuery = target.RawQuery
+	httpClient, err := rest.HTTPClientFor(a.cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	return httpClient, proxyURL, nil
+}
+
+// Rollouts returns the restricted clientset used by the kubectl rollout helpers to read and restore the revision history
+// of the provided workload kind, access is checked for the kind and for the resources that track its revisions
+func (a *AccessControlClientset) Rollouts(gvk *schema.GroupVersionKind) (kubernetes.Interface, error) {
+	revisionsGvk := &schema.GroupVersionKind{Group: appsv1.GroupName, Version: appsv1.SchemeGroupVersion.Version, Kind: "ControllerRevision"}
+	if gvk.Kind == "Deployment" {
+		revisionsGvk.Kind = "ReplicaSet"
+	}
+	return a.restrictedIfAllowed(gvk, revisionsGvk)
+}
+
+// DeploymentReplicaSets returns the restricted apps/v1 client used by the kubectl deployment utilities to list the
+// ReplicaSets of a Deployment, access is checked for ReplicaSets
+func (a *AccessControlClientset) DeploymentReplicaSets() (appsv1client.AppsV1Interface, error) {
+	gvk := &schema.GroupVersionKind{Group: appsv1.GroupName, Version: appsv1.SchemeGroupVersion.Version, Kind: "ReplicaSet"}
+	if !isAllowed(a.staticConfig, gvk) {
+		return nil, isNotAllowedError(gvk)
+	}
+	return a.restricted.AppsV1(), nil
+}
+
+// restrictedIfAllowed returns the restricted clientset for the kubectl helpers that operate on several kinds if the
+// expected kinds are allowed, the requests to any other denied kind are rejected by the clientset itself
+func (a *AccessControlClientset) restrictedIfAllowed(gvks ...*schema.GroupVersionKind) (kubernetes.Interface, error) {
+	for _, gvk := range gvks {
+		if !isAllowed(a.staticConfig, gvk) {
+			return nil, isNotAllowedError(gvk)
+		}
+	}
+	return a.restricted, nil
+}
+
 func (a *AccessControlClientset) SelfSubjectAccessReviews() (authorizationv1.SelfSubjectAccessReviewInterface, error) {
 	gvk := &schema.GroupVersionKind{Group: authorizationv1api.GroupName, Version: authorizationv1api.SchemeGroupVersion.Version, Kind: "SelfSubjectAccessReview"}
 	if !isAllowed(a.staticConfig, gvk) {
@@ -120,9 +309,22 @@ func NewAccessControlClientset(cfg *rest.Config, staticConfig *config.StaticConf
 	if err != nil {
 		return nil, err
 	}
+	hostURL, _, err := rest.DefaultServerUrlFor(cfg)
+	if err != nil {
+		return nil, err
+	}
+	restrictedCfg := rest.CopyConfig(cfg)
+	restrictedCfg.Wrap(func(original http.RoundTripper) http.RoundTripper {
+		return &accessControlRoundTripper{delegate: original, staticConfig: staticConfig, pathPrefix: strings.TrimSuffix(hostURL.Path, "/")}
+	})
+	restricted, err := kubernetes.NewForConfig(restrictedCfg)
+	if err != nil {
+		return nil, err
+	}
 	return &AccessControlClientset{
 		cfg:             cfg,
 		delegate:        clientSet,
+		restricted:      restricted,
 		discoveryClient: clientSet.DiscoveryClient,
 		metricsV1beta1:  metricsClient,
 		staticConfig:    staticConfig,
@@ -0,0 +1,69 @@
+package kubernetes
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/manusa/kubernetes-mcp-server/pkg/config"
+)
+
+// accessControlRoundTripper rejects the requests to the denied resources. It restricts the clientset provided to the
+// kubectl helpers that require a full kubernetes.Interface, these only request the built-in kinds known by the Scheme.
+type accessControlRoundTripper struct {
+	delegate     http.RoundTripper
+	staticConfig *config.StaticConfig // TODO: maybe just store the denied resource slice
+	pathPrefix   string
+}
+
+var _ http.RoundTripper = &accessControlRoundTripper{}
+
+// schemeKinds maps the resources of the kinds known by the Scheme to their kinds
+var schemeKinds = sync.OnceValue(func() map[schema.GroupVersionResource]schema.GroupVersionKind {
+	kinds := make(map[schema.GroupVersionResource]schema.GroupVersionKind)
+	for gvk := range Scheme.AllKnownTypes() {
+		gvr, _ := meta.UnsafeGuessKindToResource(gvk)
+		kinds[gvr] = gvk
+	}
+	return kinds
+})
+
+func (a *accessControlRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if gvr, ok := resourceForPath(strings.TrimPrefix(req.URL.Path, a.pathPrefix)); ok {
+		gvk, known := schemeKinds()[gvr]
+		if !known {
+			return nil, fmt.Errorf("resource not allowed: %s", gvr.String())
+		}
+		if !isAllowed(a.staticConfig, &gvk) {
+			return nil, isNotAllowedError(&gvk)
+		}
+	}
+	return a.delegate.RoundTrip(req)
+}
+
+// resourceForPath returns the resource of the API path (e.g. /api/v1/namespaces/default/pods/name/eviction -> /v1, Resource=pods),
+// false for the paths that don't target a resource (e.g. discovery)
+func resourceForPath(path string) (schema.GroupVersionResource, bool) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	var gvr schema.GroupVersionResource
+	switch {
+	case len(parts) >= 3 && parts[0] == "api":
+		gvr.Version, parts = parts[1], parts[2:]
+	case len(parts) >= 4 && parts[0] == "apis":
+		gvr.Group, gvr.Version, parts = parts[1], parts[2], parts[3:]
+	default:
+		return gvr, false
+	}
+	if parts[0] == "watch" && len(parts) > 1 {
+		parts = parts[1:]
+	}
+	gvr.Resource = parts[0]
+	if parts[0] == "namespaces" && len(parts) >= 3 {
+		gvr.Resource = parts[2]
+	}
+	return gvr, true
+}
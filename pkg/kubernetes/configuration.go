@@ -60,6 +60,19 @@ func (m *Manager) configuredNamespace() string {
 	return ""
 }
 
+// CurrentContext returns the name of the kubeconfig context the Manager is connected to
+// (in-cluster configurations are exposed with the same synthetic name used in ConfigurationView)
+func (m *Manager) CurrentContext() (string, error) {
+	if m.IsInCluster() {
+		return "context", nil
+	}
+	cfg, err := m.clientCmdConfig.RawConfig()
+	if err != nil {
+		return "", err
+	}
+	return cfg.CurrentContext, nil
+}
+
 func (m *Manager) NamespaceOrDefault(namespace string) string {
 	if namespace == "" {
 		return m.configuredNamespace()
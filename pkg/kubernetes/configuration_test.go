@@ -136,3 +136,56 @@ users:
 		}
 	})
 }
+
+func TestManager_RunSandboxInstance(t *testing.T) {
+	kubeconfig := func(server, currentContext string) *Manager {
+		kubeconfigPath := path.Join(t.TempDir(), "config")
+		kubeconfigContent := `
+apiVersion: v1
+kind: Config
+clusters:
+- cluster:
+    server: ` + server + `
+  name: example-cluster
+contexts:
+- context:
+    cluster: example-cluster
+    user: example-user
+  name: example-context
+- context:
+    cluster: example-cluster
+    user: example-user
+    namespace: other
+  name: other-context
+current-context: ` + currentContext + `
+users:
+- name: example-user
+  user:
+    token: example-token
+`
+		if err := os.WriteFile(kubeconfigPath, []byte(kubeconfigContent), 0644); err != nil {
+			t.Fatalf("failed to create kubeconfig file: %v", err)
+		}
+		m := &Manager{Kubeconfig: kubeconfigPath}
+		if err := resolveKubernetesConfigurations(m); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		return m
+	}
+	instance := kubeconfig("https://example.com", "example-context").RunSandboxInstance()
+	t.Run("with same configuration (e.g. restarted server) returns same instance", func(t *testing.T) {
+		if other := kubeconfig("https://example.com", "example-context").RunSandboxInstance(); other != instance {
+			t.Errorf("expected instance %s, got %s", instance, other)
+		}
+	})
+	t.Run("with other context returns other instance", func(t *testing.T) {
+		if other := kubeconfig("https://example.com", "other-context").RunSandboxInstance(); other == instance {
+			t.Errorf("expected other instance than %s", instance)
+		}
+	})
+	t.Run("with other cluster returns other instance", func(t *testing.T) {
+		if other := kubeconfig("https://other.example.com", "example-context").RunSandboxInstance(); other == instance {
+			t.Errorf("expected other instance than %s", instance)
+		}
+	})
+}
@@ -0,0 +1,159 @@
+package kubernetes
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+)
+
+// podsCopyTarOverhead is the room left for the tar headers and padding when capturing a file of the maximum size
+const podsCopyTarOverhead = 16 * 1024
+
+// PodsCopyFile is a regular file copied from a Pod container
+type PodsCopyFile struct {
+	Path    string
+	Mode    int64
+	Size    int64
+	ModTime time.Time
+	Content []byte
+}
+
+// PodsCopyFrom copies the regular file at the provided path out of the Pod container by streaming it as a tar archive
+// through exec (same as kubectl cp, the tar binary must be available in the container).
+// Symbolic links are followed (e.g. ConfigMap and Secret volume files), files larger than maxBytes are rejected.
+// The path must be absolute, the copy fails if tar doesn't complete before the timeout (not bounded if 0).
+func (k *Kubernetes) PodsCopyFrom(ctx context.Context, namespace, name, container, filePath string, maxBytes int64, timeout time.Duration) (*PodsCopyFile, error) {
+	if err := podsCopyValidatePath(filePath); err != nil {
+		return nil, err
+	}
+	result, err := k.PodsExec(ctx, namespace, name, PodsExecOptions{
+		Container:      container,
+		Command:        []string{"tar", "chf", "-", "--", filePath},
+		Timeout:        timeout,
+		MaxOutputBytes: int(maxBytes + podsCopyTarOverhead),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if result.TimedOut {
+		return nil, podsCopyTimeoutError(filePath, timeout)
+	}
+	reader := tar.NewReader(strings.NewReader(result.Stdout))
+	header, err := reader.Next()
+	if err != nil && result.ExitCode != 0 {
+		return nil, podsCopyError(filePath, result)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", filePath, err)
+	}
+	switch header.Typeflag {
+	case tar.TypeReg:
+	case tar.TypeDir:
+		return nil, fmt.Errorf("%s is a directory, only regular files can be copied (list the directory to find them)", filePath)
+	default:
+		return nil, fmt.Errorf("%s is not a regular file", filePath)
+	}
+	if header.Size > maxBytes {
+		return nil, fmt.Errorf("%s is %d bytes, exceeds the limit of %d bytes", filePath, header.Size, maxBytes)
+	}
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", filePath, err)
+	}
+	return &PodsCopyFile{Path: filePath, Mode: header.Mode, Size: header.Size, ModTime: header.ModTime, Content: content}, nil
+}
+
+// PodsCopyTo copies the content to the file at the provided path in the Pod container by streaming it as a tar archive
+// to the stdin of tar through exec (same as kubectl cp, the tar binary must be available in the container).
+// The path must be absolute and its parent directory must exist, an existing file is overwritten. The copy fails if tar
+// doesn't complete before the timeout (not bounded if 0).
+func (k *Kubernetes) PodsCopyTo(ctx context.Context, namespace, name, container, filePath string, content []byte, mode int64, timeout time.Duration) error {
+	if err := podsCopyValidatePath(filePath); err != nil {
+		return err
+	}
+	if strings.HasSuffix(filePath, "/") {
+		return fmt.Errorf("%s is not a valid file path", filePath)
+	}
+	archive := &bytes.Buffer{}
+	writer := tar.NewWriter(archive)
+	if err := writer.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     path.Base(filePath),
+		Mode:     mode,
+		Size:     int64(len(content)),
+		ModTime:  time.Now(),
+	}); err != nil {
+		return err
+	}
+	if _, err := writer.Write(content); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+	result, err := k.PodsExec(ctx, namespace, name, PodsExecOptions{
+		Container: container,
+		Command:   []string{"tar", "xmf", "-", "-C", path.Dir(filePath)},
+		Stdin:     archive,
+		Timeout:   timeout,
+	})
+	if err != nil {
+		return err
+	}
+	if result.TimedOut {
+		return podsCopyTimeoutError(filePath, timeout)
+	}
+	if result.ExitCode != 0 {
+		return podsCopyError(filePath, result)
+	}
+	return nil
+}
+
+// PodsListDirectory returns the long listing (ls -la) of the directory at the provided absolute path in the Pod container,
+// the listing fails if ls doesn't complete before the timeout (not bounded if 0)
+func (k *Kubernetes) PodsListDirectory(ctx context.Context, namespace, name, container, dirPath string, timeout time.Duration) (string, error) {
+	if err := podsCopyValidatePath(dirPath); err != nil {
+		return "", err
+	}
+	result, err := k.PodsExec(ctx, namespace, name, PodsExecOptions{
+		Container: container,
+		Command:   []string{"ls", "-la", "--", dirPath},
+		Timeout:   timeout,
+	})
+	if err != nil {
+		return "", err
+	}
+	if result.TimedOut {
+		return "", podsCopyTimeoutError(dirPath, timeout)
+	}
+	if result.ExitCode != 0 {
+		return "", podsCopyError(dirPath, result)
+	}
+	return result.Stdout, nil
+}
+
+// podsCopyValidatePath rejects the relative paths, an absolute path can't be read as an option of tar or ls (e.g. --checkpoint-action)
+func podsCopyValidatePath(filePath string) error {
+	if !strings.HasPrefix(filePath, "/") {
+		return fmt.Errorf("%s is not an absolute path", filePath)
+	}
+	return nil
+}
+
+// podsCopyError returns the error reported by the failed command (stderr), or its exit code if it didn't report any
+func podsCopyError(filePath string, result *PodsExecResult) error {
+	message := strings.TrimSpace(result.Stderr)
+	if message == "" {
+		return fmt.Errorf("%s: command exited with code %d", filePath, result.ExitCode)
+	}
+	return errors.New(message)
+}
+
+func podsCopyTimeoutError(filePath string, timeout time.Duration) error {
+	return fmt.Errorf("%s: command didn't complete after %s", filePath, timeout)
+}
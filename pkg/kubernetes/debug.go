@@ -0,0 +1,187 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/utils/ptr"
+
+	"github.com/manusa/kubernetes-mcp-server/pkg/version"
+)
+
+// DefaultDebugImage is the image of the debug containers if none is provided
+const DefaultDebugImage = "busybox"
+
+var debugPollInterval = time.Second
+
+// PodsDebugOptions are the options to debug a Pod with an ephemeral container or a Node with a privileged Pod
+type PodsDebugOptions struct {
+	// Image of the debug container, DefaultDebugImage if not provided
+	Image string
+	// TargetContainer is the Pod container whose process namespace is shared with the ephemeral container (Pods only)
+	TargetContainer string
+	// Timeout is the maximum duration to wait for the debug container to be running
+	Timeout time.Duration
+	// Command is executed in the debug container once it's running, if provided
+	Command []string
+	// ExecOptions are the options of the command execution (the container and command are set by the debug operation)
+	ExecOptions PodsExecOptions
+	// TTL is the time after which the run sandbox janitor deletes the Node debug Pod, it never expires if 0 (Nodes only)
+	TTL time.Duration
+}
+
+// PodsDebugResult is the outcome of a debug operation
+type PodsDebugResult struct {
+	// Namespace and Pod are the namespace and name of the debugged Pod, or of the Pod created to debug the Node
+	Namespace string
+	Pod       string
+	Container string
+	// Exec is the result of the command executed in the debug container, nil if no command was provided
+	Exec *PodsExecResult
+}
+
+// PodsDebug adds an ephemeral debug container to the Pod (same as kubectl debug pod -it --image), waits until it's running,
+// and executes the optional command in it.
+// The debug container keeps running (its stdin is kept open) so that further commands can be executed with PodsExec.
+func (k *Kubernetes) PodsDebug(ctx context.Context, namespace, name string, options PodsDebugOptions) (*PodsDebugResult, error) {
+	namespace = k.NamespaceOrDefault(namespace)
+	pods, err := k.manager.accessControlClientSet.Pods(namespace)
+	if err != nil {
+		return nil, err
+	}
+	pod, err := pods.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if options.TargetContainer != "" && !slices.ContainsFunc(pod.Spec.Containers, func(c v1.Container) bool { return c.Name == options.TargetContainer }) {
+		return nil, fmt.Errorf("target container %s not found in pod %s", options.TargetContainer, name)
+	}
+	container := "debugger-" + rand.String(5)
+	pod.Spec.EphemeralContainers = append(pod.Spec.EphemeralContainers, v1.EphemeralContainer{
+		EphemeralContainerCommon: v1.EphemeralContainerCommon{
+			Name:                     container,
+			Image:                    debugImage(options),
+			ImagePullPolicy:          v1.PullIfNotPresent,
+			Stdin:                    true,
+			TerminationMessagePolicy: v1.TerminationMessageReadFile,
+		},
+		TargetContainerName: options.TargetContainer,
+	})
+	if _, err = pods.UpdateEphemeralContainers(ctx, name, pod, metav1.UpdateOptions{FieldManager: version.BinaryName}); err != nil {
+		return nil, err
+	}
+	return k.debugWaitAndExec(ctx, namespace, name, container, options)
+}
+
+// PodsDebugNode creates a privileged Pod on the Node sharing the host namespaces and with the host filesystem mounted at /host
+// (same as kubectl debug node -it --image), waits until it's running, and executes the optional command in it.
+// The Pod is labeled as the pods_run sandbox resources and must be deleted once the debugging session is over, or is
+// deleted by the run sandbox janitor once its TTL expires.
+func (k *Kubernetes) PodsDebugNode(ctx context.Context, namespace, node string, options PodsDebugOptions) (*PodsDebugResult, error) {
+	// Ensures the Node exists and is accessible
+	if _, err := k.ResourcesGet(ctx, &schema.GroupVersionKind{Group: "", Version: "v1", Kind: "Node"}, "", node); err != nil {
+		return nil, err
+	}
+	namespace = k.NamespaceOrDefault(namespace)
+	name := version.BinaryName + "-debug-" + rand.String(5)
+	var annotations map[string]string
+	if options.TTL > 0 {
+		annotations = map[string]string{RunSandboxExpiresAtAnnotation: time.Now().Add(options.TTL).UTC().Format(time.RFC3339)}
+	}
+	pod := &v1.Pod{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: k.runSandboxLabels(name), Annotations: annotations},
+		Spec: v1.PodSpec{
+			NodeName:      node,
+			HostIPC:       true,
+			HostNetwork:   true,
+			HostPID:       true,
+			RestartPolicy: v1.RestartPolicyNever,
+			Tolerations:   []v1.Toleration{{Operator: v1.TolerationOpExists}},
+			Containers: []v1.Container{{
+				Name:            "debugger",
+				Image:           debugImage(options),
+				ImagePullPolicy: v1.PullIfNotPresent,
+				Stdin:           true,
+				SecurityContext: &v1.SecurityContext{Privileged: ptr.To(true)},
+				VolumeMounts:    []v1.VolumeMount{{Name: "host-root", MountPath: "/host"}},
+			}},
+			Volumes: []v1.Volume{{Name: "host-root", VolumeSource: v1.VolumeSource{HostPath: &v1.HostPathVolumeSource{Path: "/"}}}},
+		},
+	}
+	// Convert the Pod to Unstructured and reuse resourcesCreateOrUpdate functionality (as PodsRun)
+	m, err := runtime.DefaultUnstructuredConverter.ToUnstructured(pod)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = k.resourcesCreateOrUpdate(ctx, []*unstructured.Unstructured{{Object: m}}, false); err != nil {
+		return nil, err
+	}
+	return k.debugWaitAndExec(ctx, namespace, name, "debugger", options)
+}
+
+// debugWaitAndExec waits until the debug container is running and executes the optional command in it
+func (k *Kubernetes) debugWaitAndExec(ctx context.Context, namespace, name, container string, options PodsDebugOptions) (*PodsDebugResult, error) {
+	result := &PodsDebugResult{Namespace: namespace, Pod: name, Container: container}
+	if err := k.debugWaitForContainerRunning(ctx, namespace, name, container, options.Timeout); err != nil {
+		return result, err
+	}
+	if len(options.Command) == 0 {
+		return result, nil
+	}
+	execOptions := options.ExecOptions
+	execOptions.Container = container
+	execOptions.Command = options.Command
+	var err error
+	result.Exec, err = k.PodsExec(ctx, namespace, name, execOptions)
+	return result, err
+}
+
+// debugWaitForContainerRunning waits until the (ephemeral) container of the Pod is running, failing early if it terminates
+func (k *Kubernetes) debugWaitForContainerRunning(ctx context.Context, namespace, name, container string, timeout time.Duration) error {
+	pods, err := k.manager.accessControlClientSet.Pods(namespace)
+	if err != nil {
+		return err
+	}
+	state := "not created"
+	err = wait.PollUntilContextTimeout(ctx, debugPollInterval, timeout, true, func(ctx context.Context) (bool, error) {
+		pod, err := pods.Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		for _, status := range slices.Concat(pod.Status.ContainerStatuses, pod.Status.EphemeralContainerStatuses) {
+			if status.Name != container {
+				continue
+			}
+			switch {
+			case status.State.Running != nil:
+				return true, nil
+			case status.State.Terminated != nil:
+				return false, fmt.Errorf("container %s terminated: %s (exit code %d)", container, status.State.Terminated.Reason, status.State.Terminated.ExitCode)
+			case status.State.Waiting != nil:
+				state = "waiting: " + status.State.Waiting.Reason
+			}
+		}
+		return false, nil
+	})
+	if wait.Interrupted(err) {
+		return fmt.Errorf("container %s is not running after %s (%s)", container, timeout, state)
+	}
+	return err
+}
+
+func debugImage(options PodsDebugOptions) string {
+	if options.Image == "" {
+		return DefaultDebugImage
+	}
+	return options.Image
+}
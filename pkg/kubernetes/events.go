@@ -3,17 +3,18 @@ package kubernetes
 import (
 	"context"
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"strings"
 )
 
-func (k *Kubernetes) EventsList(ctx context.Context, namespace string) ([]map[string]any, error) {
+func (k *Kubernetes) EventsList(ctx context.Context, namespace string, options metav1.ListOptions) ([]map[string]any, error) {
 	var eventMap []map[string]any
 	raw, err := k.ResourcesList(ctx, &schema.GroupVersionKind{
 		Group: "", Version: "v1", Kind: "Event",
-	}, namespace, ResourceListOptions{})
+	}, namespace, ResourceListOptions{ListOptions: options})
 	if err != nil {
 		return eventMap, err
 	}
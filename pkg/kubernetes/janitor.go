@@ -0,0 +1,112 @@
+package kubernetes
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labelutil "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+
+	"github.com/manusa/kubernetes-mcp-server/pkg/version"
+)
+
+// RunSandboxExpiresAtAnnotation is the annotation with the RFC3339 time after which the run sandbox resources are deleted by the janitor
+var RunSandboxExpiresAtAnnotation = version.BinaryName + "/expires-at"
+
+// RunSandboxInstanceLabel is the label with the RunSandboxInstance that created the run sandbox resources
+var RunSandboxInstanceLabel = version.BinaryName + "/instance"
+
+// RunSandboxInstance identifies the configuration (cluster and kubeconfig context) of the server, the janitor only deletes
+// the run sandbox resources created with it (and not the ones of other servers sharing the cluster). It's stable across
+// restarts, so the resources created by a previous run (e.g. an ended stdio session) are deleted too once expired.
+func (m *Manager) RunSandboxInstance() string {
+	currentContext, _ := m.CurrentContext()
+	hash := sha256.Sum256([]byte(m.cfg.Host + "\n" + currentContext))
+	return hex.EncodeToString(hash[:])[:10]
+}
+
+// StartRunSandboxJanitor periodically deletes the expired run sandbox resources (Pods run with a TTL and their managed Services and Routes)
+// until StopRunSandboxJanitor or Close is called. The janitor uses the Manager credentials (not the ones of the MCP client requests).
+func (m *Manager) StartRunSandboxJanitor(interval time.Duration) {
+	m.StopRunSandboxJanitor()
+	ctx, cancel := context.WithCancel(context.Background())
+	m.stopRunSandboxJanitor = cancel
+	k := &Kubernetes{manager: m}
+	go wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if reaped, err := k.RunSandboxReap(ctx); err != nil {
+			klog.V(2).Infof("failed to reap expired run sandbox resources: %v", err)
+		} else if len(reaped) > 0 {
+			klog.V(1).Infof("reaped expired run sandbox resources: %v", reaped)
+		}
+	}, interval)
+}
+
+func (m *Manager) StopRunSandboxJanitor() {
+	if m.stopRunSandboxJanitor != nil {
+		m.stopRunSandboxJanitor()
+		m.stopRunSandboxJanitor = nil
+	}
+}
+
+// RunSandboxReap deletes the run sandbox Pods (and their managed resources, same as PodsDelete) and Services created with
+// the RunSandboxInstance of the server whose TTL expired, and returns the namespace/name of the deleted Pods and Services
+func (k *Kubernetes) RunSandboxReap(ctx context.Context) ([]string, error) {
+	selector := labelutil.Set{
+		AppKubernetesPartOf:     version.BinaryName + "-run-sandbox",
+		RunSandboxInstanceLabel: k.manager.RunSandboxInstance(),
+	}.AsSelector().String()
+	now := time.Now()
+	var reaped []string
+	pods, err := k.manager.accessControlClientSet.Pods(metav1.NamespaceAll)
+	if err != nil {
+		return nil, err
+	}
+	podList, err := pods.List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, err
+	}
+	for _, pod := range podList.Items {
+		if !runSandboxExpired(pod.ObjectMeta, now) {
+			continue
+		}
+		if _, err = k.PodsDelete(ctx, pod.Namespace, pod.Name); err != nil {
+			klog.V(2).Infof("failed to reap expired run sandbox pod %s/%s: %v", pod.Namespace, pod.Name, err)
+			continue
+		}
+		reaped = append(reaped, "pod/"+pod.Namespace+"/"+pod.Name)
+	}
+	// Services whose Pod was already deleted (e.g. manually without the managed resources cleanup)
+	services, err := k.manager.accessControlClientSet.Services(metav1.NamespaceAll)
+	if err != nil {
+		return reaped, err
+	}
+	serviceList, err := services.List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return reaped, err
+	}
+	for _, service := range serviceList.Items {
+		if !runSandboxExpired(service.ObjectMeta, now) {
+			continue
+		}
+		serviceInterface, _ := k.manager.accessControlClientSet.Services(service.Namespace)
+		if err = serviceInterface.Delete(ctx, service.Name, metav1.DeleteOptions{}); err != nil {
+			klog.V(2).Infof("failed to reap expired run sandbox service %s/%s: %v", service.Namespace, service.Name, err)
+			continue
+		}
+		reaped = append(reaped, "service/"+service.Namespace+"/"+service.Name)
+	}
+	return reaped, nil
+}
+
+func runSandboxExpired(meta metav1.ObjectMeta, now time.Time) bool {
+	expiresAt, ok := meta.Annotations[RunSandboxExpiresAtAnnotation]
+	if !ok {
+		return false
+	}
+	expiration, err := time.Parse(time.RFC3339, expiresAt)
+	return err == nil && now.After(expiration)
+}
@@ -44,8 +44,9 @@ type Manager struct {
 	accessControlRESTMapper *AccessControlRESTMapper
 	dynamicClient           *dynamic.DynamicClient
 
-	staticConfig         *config.StaticConfig
-	CloseWatchKubeConfig CloseWatchKubeConfig
+	staticConfig          *config.StaticConfig
+	CloseWatchKubeConfig  CloseWatchKubeConfig
+	stopRunSandboxJanitor context.CancelFunc
 }
 
 var Scheme = scheme.Scheme
@@ -122,6 +123,7 @@ func (m *Manager) Close() {
 	if m.CloseWatchKubeConfig != nil {
 		_ = m.CloseWatchKubeConfig()
 	}
+	m.StopRunSandboxJanitor()
 }
 
 func (m *Manager) ToDiscoveryClient() (discovery.CachedDiscoveryInterface, error) {
@@ -0,0 +1,432 @@
+package kubernetes
+
+import (
+	"bufio"
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/kubectl/pkg/describe"
+	"k8s.io/kubectl/pkg/drain"
+	"k8s.io/metrics/pkg/apis/metrics"
+	metricsv1beta1api "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+)
+
+// nodesDrainRetryInterval is the period between the eviction attempts of the Pods protected by a PodDisruptionBudget (same as kubectl drain)
+const nodesDrainRetryInterval = 5 * time.Second
+
+// nodesDrainDeletePollInterval is the period between the checks of the deletion of the evicted Pods (same as kubectl drain)
+const nodesDrainDeletePollInterval = time.Second
+
+type NodesTopOptions struct {
+	metav1.ListOptions
+	Name string
+}
+
+// NodesDrainOptions are the options to drain a Node, equivalent to the kubectl drain flags
+type NodesDrainOptions struct {
+	// Force evicts the Pods not managed by a controller (ReplicationController, ReplicaSet, Job, DaemonSet, or StatefulSet)
+	Force bool
+	// IgnoreDaemonSets skips the DaemonSet-managed Pods, the drain is aborted if there are any and this is false
+	IgnoreDaemonSets bool
+	// DeleteEmptyDirData evicts the Pods using emptyDir volumes, their local data is lost
+	DeleteEmptyDirData bool
+	// GracePeriodSeconds is the termination grace period of the evicted Pods, the Pod's own if negative
+	GracePeriodSeconds int
+	// Timeout is the maximum duration to retry the evictions rejected by a PodDisruptionBudget and to wait for the evicted
+	// Pods to be deleted, a single attempt is made if 0
+	Timeout time.Duration
+}
+
+// NodesDrainResult is the outcome of a Node drain, a Pod is evicted once it's deleted after its eviction was accepted by
+// the API server (same as kubectl drain). Terminating are the Pods whose eviction was accepted but that were not deleted
+// before the timeout expired. Warnings describe the Pods that were ignored (e.g. DaemonSet-managed).
+type NodesDrainResult struct {
+	Node        string          `json:"node"`
+	Evicted     []string        `json:"evicted,omitempty"`
+	Terminating []string        `json:"terminating,omitempty"`
+	Failed      []NodesDrainPod `json:"failed,omitempty"`
+	Warnings    string          `json:"warnings,omitempty"`
+}
+
+type NodesDrainPod struct {
+	Pod    string `json:"pod"`
+	Reason string `json:"reason"`
+}
+
+func (k *Kubernetes) NodesList(ctx context.Context, options ResourceListOptions) (runtime.Unstructured, error) {
+	return k.ResourcesList(ctx, &schema.GroupVersionKind{
+		Group: "", Version: "v1", Kind: "Node",
+	}, "", options)
+}
+
+// NodesDescribe returns the description of the Node (same as kubectl describe node), including its conditions,
+// allocatable and allocated resources, the Pods scheduled on it, and its events
+func (k *Kubernetes) NodesDescribe(_ context.Context, name string) (string, error) {
+	clientset, err := k.manager.accessControlClientSet.NodesDescribe()
+	if err != nil {
+		return "", err
+	}
+	describer := &describe.NodeDescriber{Interface: clientset}
+	return describer.Describe(metav1.NamespaceAll, name, describe.DescriberSettings{
+		ShowEvents: isAllowed(k.manager.staticConfig, &schema.GroupVersionKind{Group: "", Version: "v1", Kind: "Event"}),
+		ChunkSize:  500,
+	})
+}
+
+// NodesTop returns the resource usage metrics of the Nodes, and their allocatable resources
+func (k *Kubernetes) NodesTop(ctx context.Context, options NodesTopOptions) (*metrics.NodeMetricsList, map[string]v1.ResourceList, error) {
+	// TODO, maybe move to mcp Tools setup and omit in case metrics aren't available in the target cluster
+	if !k.supportsGroupVersion(metrics.GroupName + "/" + metricsv1beta1api.SchemeGroupVersion.Version) {
+		return nil, nil, errors.New("metrics API is not available")
+	}
+	nodeMetrics, err := k.manager.accessControlClientSet.NodesMetricses(ctx, options.Name, options.ListOptions)
+	if err != nil {
+		return nil, nil, err
+	}
+	nodes, err := k.manager.accessControlClientSet.Nodes()
+	if err != nil {
+		return nil, nil, err
+	}
+	allocatable := make(map[string]v1.ResourceList)
+	if options.Name != "" {
+		node, err := nodes.Get(ctx, options.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, nil, err
+		}
+		allocatable[node.Name] = node.Status.Allocatable
+	} else {
+		nodeList, err := nodes.List(ctx, metav1.ListOptions{LabelSelector: options.LabelSelector})
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, node := range nodeList.Items {
+			allocatable[node.Name] = node.Status.Allocatable
+		}
+	}
+	return nodeMetrics, allocatable, nil
+}
+
+// NodesCordon marks the Node as unschedulable, returns false if it already was
+func (k *Kubernetes) NodesCordon(ctx context.Context, name string) (bool, error) {
+	clientset, err := k.manager.accessControlClientSet.NodesCordon()
+	if err != nil {
+		return false, err
+	}
+	return nodesCordonOrUncordon(ctx, clientset, name, true)
+}
+
+// NodesUncordon marks the Node as schedulable, returns false if it already was
+func (k *Kubernetes) NodesUncordon(ctx context.Context, name string) (bool, error) {
+	clientset, err := k.manager.accessControlClientSet.NodesCordon()
+	if err != nil {
+		return false, err
+	}
+	return nodesCordonOrUncordon(ctx, clientset, name, false)
+}
+
+// NodesDrain cordons the Node and evicts its Pods through the eviction API so that PodDisruptionBudgets are honored.
+// Evictions rejected by a PodDisruptionBudget are retried, and the evicted Pods are waited for until they're deleted,
+// until the timeout expires. As kubectl drain, no Pod is evicted (but the Node remains cordoned) if any of them can't
+// be deleted with the provided options (e.g. unmanaged Pods without force).
+func (k *Kubernetes) NodesDrain(ctx context.Context, name string, options NodesDrainOptions) (*NodesDrainResult, error) {
+	clientset, err := k.manager.accessControlClientSet.NodesDrain()
+	if err != nil {
+		return nil, err
+	}
+	if _, err = nodesCordonOrUncordon(ctx, clientset, name, true); err != nil {
+		return nil, err
+	}
+	drainer := &drain.Helper{
+		Ctx:                 ctx,
+		Client:              clientset,
+		Force:               options.Force,
+		IgnoreAllDaemonSets: options.IgnoreDaemonSets,
+		DeleteEmptyDirData:  options.DeleteEmptyDirData,
+		GracePeriodSeconds:  options.GracePeriodSeconds,
+	}
+	podDeleteList, errs := drainer.GetPodsForDeletion(name)
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("node %s was cordoned but no Pod was evicted: %w", name, errors.Join(errs...))
+	}
+	result := &NodesDrainResult{Node: name, Warnings: podDeleteList.Warnings()}
+	evictCtx := ctx
+	if options.Timeout > 0 {
+		var cancel context.CancelFunc
+		evictCtx, cancel = context.WithTimeout(ctx, options.Timeout)
+		defer cancel()
+	}
+	pending := podDeleteList.Pods()
+	var evicted []v1.Pod
+	rejections := make(map[string]error)
+evictions:
+	for {
+		var retry []v1.Pod
+		for _, pod := range pending {
+			key := pod.Namespace + "/" + pod.Name
+			// policy/v1 Evictions are available since Kubernetes 1.22
+			err = drainer.EvictPod(pod, policyv1.SchemeGroupVersion)
+			switch {
+			case err == nil || apierrors.IsNotFound(err):
+				evicted = append(evicted, pod)
+			case apierrors.IsTooManyRequests(err):
+				// Rejected by a PodDisruptionBudget
+				rejections[key] = err
+				retry = append(retry, pod)
+			default:
+				result.Failed = append(result.Failed, NodesDrainPod{Pod: key, Reason: err.Error()})
+			}
+		}
+		pending = retry
+		if len(pending) == 0 || options.Timeout <= 0 {
+			break
+		}
+		select {
+		case <-evictCtx.Done():
+			break evictions
+		case <-time.After(nodesDrainRetryInterval):
+		}
+	}
+	for _, pod := range pending {
+		key := pod.Namespace + "/" + pod.Name
+		result.Failed = append(result.Failed, NodesDrainPod{Pod: key, Reason: rejections[key].Error()})
+	}
+	terminating := evicted
+deletions:
+	for {
+		terminating = nodesDrainTerminating(ctx, clientset, terminating)
+		if len(terminating) == 0 || options.Timeout <= 0 {
+			break
+		}
+		select {
+		case <-evictCtx.Done():
+			break deletions
+		case <-time.After(nodesDrainDeletePollInterval):
+		}
+	}
+	for _, pod := range evicted {
+		key := pod.Namespace + "/" + pod.Name
+		if slices.ContainsFunc(terminating, func(p v1.Pod) bool { return p.UID == pod.UID }) {
+			result.Terminating = append(result.Terminating, key)
+		} else {
+			result.Evicted = append(result.Evicted, key)
+		}
+	}
+	return result, nil
+}
+
+// nodesDrainTerminating returns the evicted Pods that were not deleted yet, a Pod replaced by a new one with the same name
+// (e.g. StatefulSet Pods) is deleted (same as kubectl drain)
+func nodesDrainTerminating(ctx context.Context, clientset kubernetes.Interface, pods []v1.Pod) []v1.Pod {
+	var terminating []v1.Pod
+	for _, pod := range pods {
+		current, err := clientset.CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) || (err == nil && current.UID != pod.UID) {
+			continue
+		}
+		terminating = append(terminating, pod)
+	}
+	return terminating
+}
+
+func nodesCordonOrUncordon(ctx context.Context, clientset kubernetes.Interface, name string, desired bool) (bool, error) {
+	node, err := clientset.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return false, err
+	}
+	cordonHelper := drain.NewCordonHelper(node)
+	if !cordonHelper.UpdateIfRequired(desired) {
+		return false, nil
+	}
+	// The patch error is only reported when the Node had to be replaced instead, err is the outcome of the update
+	err, _ = cordonHelper.PatchOrReplaceWithContext(ctx, clientset, false)
+	return err == nil, err
+}
+
+// NodesLogOptions are the options to read the logs of a Node through the kubelet /logs endpoint
+type NodesLogOptions struct {
+	// Query is the name of the service (e.g. kubelet) whose logs are read from the system journal, requires the
+	// NodeLogQuery feature gate and the enableSystemLogQuery kubelet configuration
+	Query string
+	// Path is the log file, or directory to list, relative to /var/log (e.g. kube-proxy.log), ignored if Query is provided
+	Path string
+	// TailLines is the number of lines to return from the end of the logs, all the lines if 0
+	TailLines int64
+	// SinceTime is the RFC3339 timestamp to return the logs from, Query only
+	SinceTime string
+	// Pattern is the regular expression the returned lines must match
+	Pattern string
+}
+
+// NodesLog returns the logs of the Node services or log files (e.g. kubelet) read through the Node proxy subresource
+func (k *Kubernetes) NodesLog(ctx context.Context, name string, options NodesLogOptions) (string, error) {
+	var pattern *regexp.Regexp
+	if options.Pattern != "" {
+		var err error
+		if pattern, err = regexp.Compile(options.Pattern); err != nil {
+			return "", fmt.Errorf("invalid pattern regular expression %s: %v", options.Pattern, err)
+		}
+	}
+	logPath := "logs/"
+	if options.Query == "" {
+		// The path can't leave /var/log (served by the kubelet /logs endpoint)
+		logFile := path.Clean("/var/log/" + options.Path)
+		if slices.Contains(strings.Split(path.Clean(options.Path), "/"), "..") ||
+			(logFile != "/var/log" && !strings.HasPrefix(logFile, "/var/log/")) {
+			return "", fmt.Errorf("invalid path %s: must be relative to /var/log", options.Path)
+		}
+		if logFile != "/var/log" {
+			logPath += strings.TrimPrefix(logFile, "/var/log/")
+			if strings.HasSuffix(options.Path, "/") {
+				logPath += "/"
+			}
+		}
+	}
+	req, err := k.manager.accessControlClientSet.NodesProxy(name, logPath)
+	if err != nil {
+		return "", err
+	}
+	// The kubelet filters the journal, the files are filtered while they're read
+	if options.Query != "" {
+		req.Param("query", options.Query)
+		if options.TailLines > 0 {
+			req.Param("tailLines", strconv.FormatInt(options.TailLines, 10))
+		}
+		if options.SinceTime != "" {
+			req.Param("sinceTime", options.SinceTime)
+		}
+		if options.Pattern != "" {
+			req.Param("pattern", options.Pattern)
+		}
+	}
+	if options.Query != "" || strings.HasSuffix(logPath, "/") {
+		ret, err := req.DoRaw(ctx)
+		return string(ret), err
+	}
+	stream, err := req.Stream(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = stream.Close() }()
+	var lines []string
+	scanner := bufio.NewScanner(stream)
+	scanner.Buffer(make([]byte, 64*1024), podsLogFollowMaxLineSize)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if pattern != nil && !pattern.MatchString(line) {
+			continue
+		}
+		lines = append(lines, line)
+		if options.TailLines > 0 && int64(len(lines)) > options.TailLines {
+			lines = lines[1:]
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return "", err
+	}
+	if len(lines) == 0 {
+		return "", nil
+	}
+	return strings.Join(lines, "\n") + "\n", nil
+}
+
+// NodesStatsSummary is the subset of the kubelet /stats/summary with the Node and Pod usage of CPU, memory, network,
+// and (ephemeral) storage
+type NodesStatsSummary struct {
+	Node NodesStatsSummaryNode  `json:"node"`
+	Pods []NodesStatsSummaryPod `json:"pods,omitempty"`
+}
+
+type NodesStatsSummaryNode struct {
+	NodeName string             `json:"nodeName"`
+	CPU      *NodesStatsCPU     `json:"cpu,omitempty"`
+	Memory   *NodesStatsMemory  `json:"memory,omitempty"`
+	Network  *NodesStatsNetwork `json:"network,omitempty"`
+	Fs       *NodesStatsFs      `json:"fs,omitempty"`
+	Runtime  *NodesStatsRuntime `json:"runtime,omitempty"`
+}
+
+type NodesStatsSummaryPod struct {
+	PodRef           NodesStatsPodReference `json:"podRef"`
+	CPU              *NodesStatsCPU         `json:"cpu,omitempty"`
+	Memory           *NodesStatsMemory      `json:"memory,omitempty"`
+	Network          *NodesStatsNetwork     `json:"network,omitempty"`
+	EphemeralStorage *NodesStatsFs          `json:"ephemeral-storage,omitempty"`
+}
+
+type NodesStatsPodReference struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
+type NodesStatsCPU struct {
+	UsageNanoCores *uint64 `json:"usageNanoCores,omitempty"`
+}
+
+type NodesStatsMemory struct {
+	AvailableBytes  *uint64 `json:"availableBytes,omitempty"`
+	WorkingSetBytes *uint64 `json:"workingSetBytes,omitempty"`
+}
+
+type NodesStatsNetwork struct {
+	RxBytes *uint64 `json:"rxBytes,omitempty"`
+	TxBytes *uint64 `json:"txBytes,omitempty"`
+}
+
+type NodesStatsFs struct {
+	AvailableBytes *uint64 `json:"availableBytes,omitempty"`
+	CapacityBytes  *uint64 `json:"capacityBytes,omitempty"`
+	UsedBytes      *uint64 `json:"usedBytes,omitempty"`
+}
+
+type NodesStatsRuntime struct {
+	ImageFs     *NodesStatsFs `json:"imageFs,omitempty"`
+	ContainerFs *NodesStatsFs `json:"containerFs,omitempty"`
+}
+
+// NodesStatsSummary returns the resource usage of the Node and its Pods (optionally only those in the provided namespace)
+// read from the kubelet /stats/summary endpoint, the Pods are sorted by their ephemeral storage usage
+func (k *Kubernetes) NodesStatsSummary(ctx context.Context, name, namespace string) (*NodesStatsSummary, error) {
+	req, err := k.manager.accessControlClientSet.NodesProxy(name, "stats/summary")
+	if err != nil {
+		return nil, err
+	}
+	raw, err := req.DoRaw(ctx)
+	if err != nil {
+		return nil, err
+	}
+	summary := &NodesStatsSummary{}
+	if err = json.Unmarshal(raw, summary); err != nil {
+		return nil, fmt.Errorf("failed to parse the stats summary of node %s: %v", name, err)
+	}
+	if namespace != "" {
+		summary.Pods = slices.DeleteFunc(summary.Pods, func(pod NodesStatsSummaryPod) bool {
+			return pod.PodRef.Namespace != namespace
+		})
+	}
+	ephemeralStorageUsed := func(pod NodesStatsSummaryPod) uint64 {
+		if pod.EphemeralStorage == nil || pod.EphemeralStorage.UsedBytes == nil {
+			return 0
+		}
+		return *pod.EphemeralStorage.UsedBytes
+	}
+	slices.SortStableFunc(summary.Pods, func(a, b NodesStatsSummaryPod) int {
+		return cmp.Compare(ephemeralStorageUsed(b), ephemeralStorageUsed(a))
+	})
+	return summary, nil
+}
@@ -1,11 +1,21 @@
 package kubernetes
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"maps"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
+	"golang.org/x/sync/errgroup"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -14,7 +24,10 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/remotecommand"
+	utilexec "k8s.io/client-go/util/exec"
+	"k8s.io/kubectl/pkg/cmd/util/podcmd"
 	"k8s.io/metrics/pkg/apis/metrics"
 	metricsv1beta1api "k8s.io/metrics/pkg/apis/metrics/v1beta1"
 
@@ -92,16 +105,18 @@ func (k *Kubernetes) PodsDelete(ctx context.Context, namespace, name string) (st
 		k.ResourcesDelete(ctx, &schema.GroupVersionKind{Group: "", Version: "v1", Kind: "Pod"}, namespace, name)
 }
 
-func (k *Kubernetes) PodsLog(ctx context.Context, namespace, name, container string) (string, error) {
-	tailLines := int64(256)
-	pods, err := k.manager.accessControlClientSet.Pods(k.NamespaceOrDefault(namespace))
+// PodsLogOptions are the options to retrieve the logs of a Pod container
+type PodsLogOptions struct {
+	v1.PodLogOptions
+	// Grep is a regular expression to filter the retrieved log lines, only the matching lines are returned
+	Grep string
+}
+
+func (k *Kubernetes) PodsLog(ctx context.Context, namespace, name string, options PodsLogOptions) (string, error) {
+	req, grep, err := k.podsLogRequest(ctx, namespace, name, options)
 	if err != nil {
 		return "", err
 	}
-	req := pods.GetLogs(name, &v1.PodLogOptions{
-		TailLines: &tailLines,
-		Container: container,
-	})
 	res := req.Do(ctx)
 	if res.Error() != nil {
 		return "", res.Error()
@@ -110,36 +125,311 @@ func (k *Kubernetes) PodsLog(ctx context.Context, namespace, name, container str
 	if err != nil {
 		return "", err
 	}
-	return string(rawData), nil
+	if grep == nil {
+		return string(rawData), nil
+	}
+	var filtered strings.Builder
+	for _, line := range strings.SplitAfter(string(rawData), "\n") {
+		if grep.MatchString(line) {
+			filtered.WriteString(line)
+		}
+	}
+	return filtered.String(), nil
+}
+
+// podsLogFollowMaxLineSize is the maximum size of a followed log line, longer lines stop the stream with an error
+const podsLogFollowMaxLineSize = 1024 * 1024
+
+// PodsLogFollow streams the logs of the Pod container and calls onLine for every new line (matching the grep expression, if any).
+// It returns when the context is done, the log stream ends (e.g. the container terminates), or onLine returns false.
+func (k *Kubernetes) PodsLogFollow(ctx context.Context, namespace, name string, options PodsLogOptions, onLine func(line string) bool) error {
+	options.Follow = true
+	req, grep, err := k.podsLogRequest(ctx, namespace, name, options)
+	if err != nil {
+		return err
+	}
+	stream, err := req.Stream(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = stream.Close() }()
+	scanner := bufio.NewScanner(stream)
+	scanner.Buffer(make([]byte, 64*1024), podsLogFollowMaxLineSize)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if grep != nil && !grep.MatchString(line) {
+			continue
+		}
+		if !onLine(line) {
+			return nil
+		}
+	}
+	// Reading the stream fails once the context is cancelled or its deadline is exceeded, which is the expected way to stop following
+	if ctx.Err() != nil {
+		return nil
+	}
+	return scanner.Err()
+}
+
+// podsLogRequest returns the log request for the Pod container (defaulted if not provided) and the compiled grep expression, if any
+func (k *Kubernetes) podsLogRequest(ctx context.Context, namespace, name string, options PodsLogOptions) (*rest.Request, *regexp.Regexp, error) {
+	var grep *regexp.Regexp
+	if options.Grep != "" {
+		var err error
+		if grep, err = regexp.Compile(options.Grep); err != nil {
+			return nil, nil, fmt.Errorf("invalid grep regular expression %s: %v", options.Grep, err)
+		}
+	}
+	pods, err := k.manager.accessControlClientSet.Pods(k.NamespaceOrDefault(namespace))
+	if err != nil {
+		return nil, nil, err
+	}
+	if options.Container == "" {
+		pod, err := pods.Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, nil, err
+		}
+		if options.Container, err = podsDefaultContainer(pod); err != nil {
+			return nil, nil, err
+		}
+	}
+	return pods.GetLogs(name, &options.PodLogOptions), grep, nil
+}
+
+// podsDefaultContainer returns the container to use when none is specified (same as kubectl, the default-container annotation or the only container).
+// For Pods with multiple containers, the returned error lists the available ones.
+func podsDefaultContainer(pod *v1.Pod) (string, error) {
+	if defaultContainer := pod.Annotations[podcmd.DefaultContainerAnnotationName]; defaultContainer != "" {
+		return defaultContainer, nil
+	}
+	if len(pod.Spec.Containers) == 1 {
+		return pod.Spec.Containers[0].Name, nil
+	}
+	containers := make([]string, 0, len(pod.Spec.Containers))
+	for _, container := range pod.Spec.Containers {
+		containers = append(containers, container.Name)
+	}
+	message := fmt.Sprintf("pod %s has %d containers, a container name must be specified, choose one of: %s",
+		pod.Name, len(containers), strings.Join(containers, ", "))
+	if len(pod.Spec.InitContainers) > 0 {
+		initContainers := make([]string, 0, len(pod.Spec.InitContainers))
+		for _, container := range pod.Spec.InitContainers {
+			initContainers = append(initContainers, container.Name)
+		}
+		message += fmt.Sprintf(" (or one of the init containers: %s)", strings.Join(initContainers, ", "))
+	}
+	return "", errors.New(message)
+}
+
+// PodsLogAggregateOptions are the options to retrieve the merged logs of the containers of the Pods matching a label selector or owned by a workload
+type PodsLogAggregateOptions struct {
+	// PodsLogOptions are applied to each of the containers, if Container is set only the logs of that container are retrieved
+	PodsLogOptions
+	LabelSelector string
+	// OwnerKind (one of PodsLogOwnerKinds) and OwnerName select the Pods using the owner label selector
+	OwnerKind string
+	OwnerName string
+}
+
+// PodsLogOwnerKinds are the kinds of the Pod owners supported by PodsLogAggregate
+var PodsLogOwnerKinds = map[string]schema.GroupVersionKind{
+	"Deployment":  {Group: "apps", Version: "v1", Kind: "Deployment"},
+	"StatefulSet": {Group: "apps", Version: "v1", Kind: "StatefulSet"},
+	"DaemonSet":   {Group: "apps", Version: "v1", Kind: "DaemonSet"},
+	"ReplicaSet":  {Group: "apps", Version: "v1", Kind: "ReplicaSet"},
+	"Job":         {Group: "batch", Version: "v1", Kind: "Job"},
+}
+
+// podsLogAggregateConcurrency is the maximum number of container logs retrieved in parallel
+const podsLogAggregateConcurrency = 5
+
+type podsLogLine struct {
+	timestamp time.Time
+	text      string
+}
+
+// PodsLogAggregate retrieves the logs of all the containers of the matching Pods and merges them by timestamp.
+// Each line is prefixed with [pod/container], lines from containers that failed to return their logs report the error instead.
+func (k *Kubernetes) PodsLogAggregate(ctx context.Context, namespace string, options PodsLogAggregateOptions) (string, error) {
+	namespace = k.NamespaceOrDefault(namespace)
+	labelSelector := options.LabelSelector
+	var err error
+	if options.OwnerKind != "" {
+		if labelSelector, err = k.podsOwnerSelector(ctx, namespace, options.OwnerKind, options.OwnerName); err != nil {
+			return "", err
+		}
+	}
+	if labelSelector == "" {
+		return "", errors.New("a label selector or an owner is required to select the Pods")
+	}
+	// The lines are filtered once their timestamp is removed, so that anchored expressions match the log messages
+	var grep *regexp.Regexp
+	if options.Grep != "" {
+		if grep, err = regexp.Compile(options.Grep); err != nil {
+			return "", fmt.Errorf("invalid grep regular expression %s: %v", options.Grep, err)
+		}
+	}
+	podList, err := k.PodsListInNamespace(ctx, namespace, ResourceListOptions{ListOptions: metav1.ListOptions{LabelSelector: labelSelector}})
+	if err != nil {
+		return "", err
+	}
+	pods := &v1.PodList{}
+	if err = runtime.DefaultUnstructuredConverter.FromUnstructured(podList.UnstructuredContent(), pods); err != nil {
+		return "", err
+	}
+	if len(pods.Items) == 0 {
+		return "", fmt.Errorf("no pods found in namespace %s matching the label selector %s", namespace, labelSelector)
+	}
+	var mutex sync.Mutex
+	lines := make([]podsLogLine, 0)
+	tasks := errgroup.Group{}
+	tasks.SetLimit(podsLogAggregateConcurrency)
+	for _, pod := range pods.Items {
+		for _, container := range pod.Spec.Containers {
+			if options.Container != "" && options.Container != container.Name {
+				continue
+			}
+			tasks.Go(func() error {
+				containerLines := k.podsLogLines(ctx, namespace, pod.Name, container.Name, options.PodsLogOptions, grep)
+				mutex.Lock()
+				defer mutex.Unlock()
+				lines = append(lines, containerLines...)
+				return nil
+			})
+		}
+	}
+	_ = tasks.Wait()
+	sort.SliceStable(lines, func(i, j int) bool {
+		return lines[i].timestamp.Before(lines[j].timestamp)
+	})
+	var ret strings.Builder
+	for _, line := range lines {
+		ret.WriteString(line.text)
+		ret.WriteString("\n")
+	}
+	return ret.String(), nil
+}
+
+// podsLogLines returns the prefixed log lines of the Pod container along with their timestamp (always requested to merge the lines).
+// Only the lines whose message (without the timestamp) matches grep are returned, if provided.
+func (k *Kubernetes) podsLogLines(ctx context.Context, namespace, pod, container string, options PodsLogOptions, grep *regexp.Regexp) []podsLogLine {
+	prefix := "[" + pod + "/" + container + "] "
+	timestamps := options.Timestamps
+	options.Container = container
+	options.Timestamps = true
+	options.Grep = ""
+	logs, err := k.PodsLog(ctx, namespace, pod, options)
+	if err != nil {
+		return []podsLogLine{{text: prefix + "failed to get logs: " + err.Error()}}
+	}
+	lines := make([]podsLogLine, 0)
+	var lastTimestamp time.Time
+	for _, line := range strings.Split(strings.TrimRight(logs, "\n"), "\n") {
+		if line == "" {
+			continue
+		}
+		message := line
+		if rawTimestamp, rest, found := strings.Cut(line, " "); found {
+			if timestamp, err := time.Parse(time.RFC3339Nano, rawTimestamp); err == nil {
+				lastTimestamp = timestamp
+				message = rest
+			}
+		}
+		if grep != nil && !grep.MatchString(message) {
+			continue
+		}
+		if !timestamps {
+			line = message
+		}
+		lines = append(lines, podsLogLine{timestamp: lastTimestamp, text: prefix + line})
+	}
+	return lines
 }
 
-func (k *Kubernetes) PodsRun(ctx context.Context, namespace, name, image string, port int32) ([]*unstructured.Unstructured, error) {
+// podsOwnerSelector returns the label selector of the Pods owned by the provided workload
+func (k *Kubernetes) podsOwnerSelector(ctx context.Context, namespace, ownerKind, ownerName string) (string, error) {
+	gvk, ok := PodsLogOwnerKinds[ownerKind]
+	if !ok {
+		return "", fmt.Errorf("owner kind %s is not supported", ownerKind)
+	}
+	if ownerName == "" {
+		return "", errors.New("the owner name is required")
+	}
+	owner, err := k.ResourcesGet(ctx, &gvk, namespace, ownerName)
+	if err != nil {
+		return "", err
+	}
+	rawSelector, found, err := unstructured.NestedMap(owner.Object, "spec", "selector")
+	if err != nil || !found {
+		return "", fmt.Errorf("%s %s has no Pod selector", ownerKind, ownerName)
+	}
+	labelSelector := &metav1.LabelSelector{}
+	if err = runtime.DefaultUnstructuredConverter.FromUnstructured(rawSelector, labelSelector); err != nil {
+		return "", err
+	}
+	selector, err := metav1.LabelSelectorAsSelector(labelSelector)
+	if err != nil {
+		return "", err
+	}
+	return selector.String(), nil
+}
+
+// PodsRunOptions are the options of the Pod (and Service and Route if a port is exposed) run by PodsRun
+type PodsRunOptions struct {
+	Image string
+	// Port is the container port exposed through a Service (and a Route in OpenShift), no port is exposed if 0
+	Port           int32
+	Command        []string
+	Args           []string
+	Env            map[string]string
+	Resources      v1.ResourceRequirements
+	ServiceAccount string
+	RestartPolicy  v1.RestartPolicy
+	NodeSelector   map[string]string
+	// TTL is the time after which the run sandbox janitor deletes the Pod and its managed resources, they never expire if 0
+	TTL time.Duration
+}
+
+func (k *Kubernetes) PodsRun(ctx context.Context, namespace, name string, options PodsRunOptions) ([]*unstructured.Unstructured, error) {
 	if name == "" {
 		name = version.BinaryName + "-run-" + rand.String(5)
 	}
-	labels := map[string]string{
-		AppKubernetesName:      name,
-		AppKubernetesComponent: name,
-		AppKubernetesManagedBy: version.BinaryName,
-		AppKubernetesPartOf:    version.BinaryName + "-run-sandbox",
+	labels := k.runSandboxLabels(name)
+	var annotations map[string]string
+	if options.TTL > 0 {
+		annotations = map[string]string{RunSandboxExpiresAtAnnotation: time.Now().Add(options.TTL).UTC().Format(time.RFC3339)}
+	}
+	var env []v1.EnvVar
+	for _, key := range slices.Sorted(maps.Keys(options.Env)) {
+		env = append(env, v1.EnvVar{Name: key, Value: options.Env[key]})
 	}
 	// NewPod
 	var resources []any
 	pod := &v1.Pod{
 		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
-		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: k.NamespaceOrDefault(namespace), Labels: labels},
-		Spec: v1.PodSpec{Containers: []v1.Container{{
-			Name:            name,
-			Image:           image,
-			ImagePullPolicy: v1.PullAlways,
-		}}},
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: k.NamespaceOrDefault(namespace), Labels: labels, Annotations: annotations},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{{
+				Name:            name,
+				Image:           options.Image,
+				ImagePullPolicy: v1.PullAlways,
+				Command:         options.Command,
+				Args:            options.Args,
+				Env:             env,
+				Resources:       options.Resources,
+			}},
+			ServiceAccountName: options.ServiceAccount,
+			RestartPolicy:      options.RestartPolicy,
+			NodeSelector:       options.NodeSelector,
+		},
 	}
 	resources = append(resources, pod)
+	port := options.Port
 	if port > 0 {
 		pod.Spec.Containers[0].Ports = []v1.ContainerPort{{ContainerPort: port}}
 		resources = append(resources, &v1.Service{
 			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Service"},
-			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: k.NamespaceOrDefault(namespace), Labels: labels},
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: k.NamespaceOrDefault(namespace), Labels: labels, Annotations: annotations},
 			Spec: v1.ServiceSpec{
 				Selector: labels,
 				Type:     v1.ServiceTypeClusterIP,
@@ -190,7 +480,18 @@ func (k *Kubernetes) PodsRun(ctx context.Context, namespace, name, image string,
 		}
 		toCreate = append(toCreate, u)
 	}
-	return k.resourcesCreateOrUpdate(ctx, toCreate)
+	return k.resourcesCreateOrUpdate(ctx, toCreate, false)
+}
+
+// runSandboxLabels returns the labels of the resources created by the server to run workloads (pods_run sandbox, node debug pods)
+func (k *Kubernetes) runSandboxLabels(name string) map[string]string {
+	return map[string]string{
+		AppKubernetesName:       name,
+		AppKubernetesComponent:  name,
+		AppKubernetesManagedBy:  version.BinaryName,
+		AppKubernetesPartOf:     version.BinaryName + "-run-sandbox",
+		RunSandboxInstanceLabel: k.manager.RunSandboxInstance(),
+	}
 }
 
 func (k *Kubernetes) PodsTop(ctx context.Context, options PodsTopOptions) (*metrics.PodMetricsList, error) {
@@ -207,45 +508,107 @@ func (k *Kubernetes) PodsTop(ctx context.Context, options PodsTopOptions) (*metr
 	return k.manager.accessControlClientSet.PodsMetricses(ctx, namespace, options.Name, options.ListOptions)
 }
 
-func (k *Kubernetes) PodsExec(ctx context.Context, namespace, name, container string, command []string) (string, error) {
+// PodsExecOptions are the options to execute a command in a Pod container
+type PodsExecOptions struct {
+	// Container is the name of the container to execute the command in, the first container of the Pod if not provided
+	Container string
+	Command   []string
+	// Stdin is sent to the standard input of the command, if provided
+	Stdin io.Reader
+	// Timeout is the maximum duration of the command execution, the command is not bounded if 0
+	Timeout time.Duration
+	// MaxOutputBytes is the maximum number of bytes captured from each of the stdout and stderr streams, unbounded if 0
+	MaxOutputBytes int
+}
+
+// PodsExecResult is the outcome of a command executed in a Pod container
+type PodsExecResult struct {
+	Stdout          string
+	Stderr          string
+	StdoutTruncated bool
+	StderrTruncated bool
+	// ExitCode is the exit code of the command, only meaningful if the command completed (didn't time out)
+	ExitCode int
+	// TimedOut is true if the command didn't complete before the timeout, the captured output is partial
+	TimedOut bool
+}
+
+func (k *Kubernetes) PodsExec(ctx context.Context, namespace, name string, options PodsExecOptions) (*PodsExecResult, error) {
 	namespace = k.NamespaceOrDefault(namespace)
 	pods, err := k.manager.accessControlClientSet.Pods(namespace)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	pod, err := pods.Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	// https://github.com/kubernetes/kubectl/blob/5366de04e168bcbc11f5e340d131a9ca8b7d0df4/pkg/cmd/exec/exec.go#L350-L352
 	if pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed {
-		return "", fmt.Errorf("cannot exec into a container in a completed pod; current phase is %s", pod.Status.Phase)
+		return nil, fmt.Errorf("cannot exec into a container in a completed pod; current phase is %s", pod.Status.Phase)
 	}
+	container := options.Container
 	if container == "" {
 		container = pod.Spec.Containers[0].Name
 	}
 	podExecOptions := &v1.PodExecOptions{
 		Container: container,
-		Command:   command,
+		Command:   options.Command,
+		Stdin:     options.Stdin != nil,
 		Stdout:    true,
 		Stderr:    true,
 	}
 	executor, err := k.manager.accessControlClientSet.PodsExec(namespace, name, podExecOptions)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	stdout := bytes.NewBuffer(make([]byte, 0))
-	stderr := bytes.NewBuffer(make([]byte, 0))
-	if err = executor.StreamWithContext(ctx, remotecommand.StreamOptions{
-		Stdout: stdout, Stderr: stderr, Tty: false,
-	}); err != nil {
-		return "", err
+	streamCtx := ctx
+	if options.Timeout > 0 {
+		var cancel context.CancelFunc
+		streamCtx, cancel = context.WithTimeout(ctx, options.Timeout)
+		defer cancel()
 	}
-	if stdout.Len() > 0 {
-		return stdout.String(), nil
+	stdout := &limitedBuffer{limit: options.MaxOutputBytes}
+	stderr := &limitedBuffer{limit: options.MaxOutputBytes}
+	err = executor.StreamWithContext(streamCtx, remotecommand.StreamOptions{
+		Stdin: options.Stdin, Stdout: stdout, Stderr: stderr, Tty: false,
+	})
+	result := &PodsExecResult{
+		Stdout:          stdout.String(),
+		Stderr:          stderr.String(),
+		StdoutTruncated: stdout.truncated,
+		StderrTruncated: stderr.truncated,
 	}
-	if stderr.Len() > 0 {
-		return stderr.String(), nil
+	var exitError utilexec.ExitError
+	switch {
+	case err == nil:
+	case errors.As(err, &exitError) && exitError.Exited():
+		result.ExitCode = exitError.ExitStatus()
+	case ctx.Err() == nil && streamCtx.Err() != nil:
+		result.TimedOut = true
+	default:
+		return nil, err
 	}
-	return "", nil
+	return result, nil
+}
+
+// limitedBuffer is a buffer that discards (without failing) the writes exceeding its limit, if any.
+// The buffer is not embedded so that io.Copy can't bypass the limit through bytes.Buffer.ReadFrom.
+type limitedBuffer struct {
+	buffer    bytes.Buffer
+	limit     int
+	truncated bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if b.limit > 0 && b.buffer.Len()+len(p) > b.limit {
+		b.truncated = true
+		_, _ = b.buffer.Write(p[:max(b.limit-b.buffer.Len(), 0)])
+		return len(p), nil
+	}
+	return b.buffer.Write(p)
+}
+
+func (b *limitedBuffer) String() string {
+	return b.buffer.String()
 }
@@ -0,0 +1,290 @@
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labelutil "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/portforward"
+)
+
+const (
+	// HTTPProbeModeProxy performs the request through the API server proxy subresource of the Pod or Service
+	HTTPProbeModeProxy = "proxy"
+	// HTTPProbeModePortForward performs the request through a temporary port-forward to the Pod (or to a ready Pod of the Service)
+	HTTPProbeModePortForward = "port-forward"
+)
+
+// httpProbeHopByHopHeaders are the hop-by-hop headers, they're meaningful for a single connection and never forwarded
+var httpProbeHopByHopHeaders = []string{"Connection", "Keep-Alive", "Proxy-Authenticate", "Proxy-Authorization", "Proxy-Connection", "Te", "Trailer", "Transfer-Encoding", "Upgrade"}
+
+// HTTPProbeOptions are the options of an HTTP request performed against a Pod or Service port
+type HTTPProbeOptions struct {
+	// Kind of the target, Pod or Service
+	Kind string
+	Name string
+	// Port is the number or name of the Pod container port or Service port
+	Port string
+	// Scheme is http or https, certificates are not verified for https requests through a port-forward
+	Scheme string
+	Method string
+	Path   string
+	// Headers of the request, hop-by-hop headers are not sent, neither are the credential and impersonation headers
+	// (Authorization, Cookie, Impersonate-*) through the API server proxy (these would be processed by the API server)
+	Headers map[string]string
+	Body    string
+	// Mode is HTTPProbeModeProxy or HTTPProbeModePortForward
+	Mode    string
+	Timeout time.Duration
+	// MaxBodyBytes is the maximum number of bytes of the response body returned, unbounded if 0
+	MaxBodyBytes int
+}
+
+// HTTPProbeResult is the response of an HTTP probe
+type HTTPProbeResult struct {
+	Status        string
+	StatusCode    int
+	Headers       http.Header
+	Body          string
+	BodyTruncated bool
+	Duration      time.Duration
+	// Target describes the probed endpoint, e.g. the Pod and port selected for a Service port-forward
+	Target string
+	// DroppedHeaders are the provided request headers that were not sent
+	DroppedHeaders []string
+}
+
+// HTTPProbe performs an HTTP request to the Pod or Service port and returns the response status, headers, and (truncated) body.
+// No HTTP client (e.g. curl) is required in the target container image.
+func (k *Kubernetes) HTTPProbe(ctx context.Context, namespace string, options HTTPProbeOptions) (*HTTPProbeResult, error) {
+	namespace = k.NamespaceOrDefault(namespace)
+	if options.Kind != "Pod" && options.Kind != "Service" {
+		return nil, fmt.Errorf("http probe is not supported for %s, supported kinds are: Pod, Service", options.Kind)
+	}
+	if options.Scheme == "" {
+		options.Scheme = "http"
+	}
+	if options.Method == "" {
+		options.Method = http.MethodGet
+	}
+	if !strings.HasPrefix(options.Path, "/") {
+		options.Path = "/" + options.Path
+	}
+	if options.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, options.Timeout)
+		defer cancel()
+	}
+	switch options.Mode {
+	case HTTPProbeModeProxy, "":
+		return k.httpProbeProxy(ctx, namespace, options)
+	case HTTPProbeModePortForward:
+		return k.httpProbePortForward(ctx, namespace, options)
+	default:
+		return nil, fmt.Errorf("http probe mode %s is not supported, supported modes are: %s, %s", options.Mode, HTTPProbeModeProxy, HTTPProbeModePortForward)
+	}
+}
+
+func (k *Kubernetes) httpProbeProxy(ctx context.Context, namespace string, options HTTPProbeOptions) (*HTTPProbeResult, error) {
+	proxy := k.manager.accessControlClientSet.ServicesProxy
+	if options.Kind == "Pod" {
+		proxy = k.manager.accessControlClientSet.PodsProxy
+	}
+	httpClient, proxyURL, err := proxy(namespace, options.Name, options.Scheme, options.Port, options.Path)
+	if err != nil {
+		return nil, err
+	}
+	result, err := httpProbeRequest(ctx, httpClient, proxyURL, options)
+	if err != nil {
+		return nil, err
+	}
+	result.Target = fmt.Sprintf("%s %s/%s port %s (API server proxy)", options.Kind, namespace, options.Name, options.Port)
+	return result, nil
+}
+
+func (k *Kubernetes) httpProbePortForward(ctx context.Context, namespace string, options HTTPProbeOptions) (*HTTPProbeResult, error) {
+	pod, port, err := k.httpProbePortForwardTarget(ctx, namespace, options)
+	if err != nil {
+		return nil, err
+	}
+	dialer, err := k.manager.accessControlClientSet.PodsPortForward(namespace, pod.Name)
+	if err != nil {
+		return nil, err
+	}
+	stopChan, readyChan := make(chan struct{}), make(chan struct{})
+	defer close(stopChan)
+	errOut := &bytes.Buffer{}
+	forwarder, err := portforward.NewOnAddresses(dialer, []string{"127.0.0.1"}, []string{fmt.Sprintf("0:%d", port)}, stopChan, readyChan, io.Discard, errOut)
+	if err != nil {
+		return nil, err
+	}
+	forwardErr := make(chan error, 1)
+	go func() { forwardErr <- forwarder.ForwardPorts() }()
+	select {
+	case <-readyChan:
+	case err = <-forwardErr:
+		return nil, fmt.Errorf("failed to forward port %d of pod %s: %v", port, pod.Name, err)
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	forwardedPorts, err := forwarder.GetPorts()
+	if err != nil {
+		return nil, err
+	}
+	target := &url.URL{Scheme: options.Scheme, Host: fmt.Sprintf("127.0.0.1:%d", forwardedPorts[0].Local)}
+	if target, err = target.Parse(options.Path); err != nil {
+		return nil, err
+	}
+	// The Pod certificate can't be verified for the forwarded local address
+	transport := &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}
+	// The keep-alive connection to the forwarded port is closed before the port-forward is stopped
+	defer transport.CloseIdleConnections()
+	result, err := httpProbeRequest(ctx, &http.Client{Transport: transport}, target, options)
+	if err != nil && errOut.Len() > 0 {
+		return nil, fmt.Errorf("%v: %s", err, strings.TrimSpace(errOut.String()))
+	} else if err != nil {
+		return nil, err
+	}
+	result.Target = fmt.Sprintf("Pod %s/%s port %d (port-forward)", namespace, pod.Name, port)
+	return result, nil
+}
+
+// httpProbePortForwardTarget returns the Pod and container port to forward, for Services a running and ready Pod selected
+// by the Service is chosen, and the Service port is resolved to its target port (same as kubectl port-forward svc/name)
+func (k *Kubernetes) httpProbePortForwardTarget(ctx context.Context, namespace string, options HTTPProbeOptions) (*v1.Pod, int32, error) {
+	pods, err := k.manager.accessControlClientSet.Pods(namespace)
+	if err != nil {
+		return nil, 0, err
+	}
+	if options.Kind == "Pod" {
+		pod, err := pods.Get(ctx, options.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, 0, err
+		}
+		port, err := httpProbeContainerPort(pod, intstr.Parse(options.Port))
+		return pod, port, err
+	}
+	services, err := k.manager.accessControlClientSet.Services(namespace)
+	if err != nil {
+		return nil, 0, err
+	}
+	service, err := services.Get(ctx, options.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, 0, err
+	}
+	servicePortIndex := slices.IndexFunc(service.Spec.Ports, func(p v1.ServicePort) bool {
+		return p.Name == options.Port || strconv.Itoa(int(p.Port)) == options.Port
+	})
+	if servicePortIndex < 0 {
+		return nil, 0, fmt.Errorf("service %s has no port %s", service.Name, options.Port)
+	}
+	if len(service.Spec.Selector) == 0 {
+		return nil, 0, fmt.Errorf("service %s has no selector, port-forward is not supported", service.Name)
+	}
+	podList, err := pods.List(ctx, metav1.ListOptions{LabelSelector: labelutil.SelectorFromSet(service.Spec.Selector).String()})
+	if err != nil {
+		return nil, 0, err
+	}
+	for i := range podList.Items {
+		if pod := &podList.Items[i]; pod.Status.Phase == v1.PodRunning && httpProbePodReady(pod) {
+			targetPort := service.Spec.Ports[servicePortIndex].TargetPort
+			if targetPort.Type == intstr.Int && targetPort.IntVal == 0 {
+				targetPort = intstr.FromInt32(service.Spec.Ports[servicePortIndex].Port)
+			}
+			port, err := httpProbeContainerPort(pod, targetPort)
+			return pod, port, err
+		}
+	}
+	return nil, 0, fmt.Errorf("service %s has no running and ready pods", service.Name)
+}
+
+// httpProbeContainerPort returns the number of the Pod container port, named ports are resolved from the container definitions
+func httpProbeContainerPort(pod *v1.Pod, port intstr.IntOrString) (int32, error) {
+	if port.Type == intstr.Int {
+		return port.IntVal, nil
+	}
+	for _, container := range pod.Spec.Containers {
+		for _, containerPort := range container.Ports {
+			if containerPort.Name == port.StrVal {
+				return containerPort.ContainerPort, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("pod %s has no container port named %s", pod.Name, port.StrVal)
+}
+
+func httpProbePodReady(pod *v1.Pod) bool {
+	return slices.ContainsFunc(pod.Status.Conditions, func(c v1.PodCondition) bool {
+		return c.Type == v1.PodReady && c.Status == v1.ConditionTrue
+	})
+}
+
+func httpProbeRequest(ctx context.Context, httpClient *http.Client, target *url.URL, options HTTPProbeOptions) (*HTTPProbeResult, error) {
+	var body io.Reader
+	if options.Body != "" {
+		body = strings.NewReader(options.Body)
+	}
+	req, err := http.NewRequestWithContext(ctx, options.Method, target.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	var droppedHeaders []string
+	for key, value := range options.Headers {
+		if httpProbeDroppedHeader(key, options.Mode != HTTPProbeModePortForward) {
+			droppedHeaders = append(droppedHeaders, key)
+			continue
+		}
+		req.Header.Set(key, value)
+	}
+	slices.Sort(droppedHeaders)
+	start := time.Now()
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = res.Body.Close() }()
+	reader := io.Reader(res.Body)
+	if options.MaxBodyBytes > 0 {
+		reader = io.LimitReader(res.Body, int64(options.MaxBodyBytes)+1)
+	}
+	responseBody, err := io.ReadAll(reader)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	result := &HTTPProbeResult{
+		Status:         res.Status,
+		StatusCode:     res.StatusCode,
+		Headers:        res.Header,
+		Duration:       time.Since(start),
+		DroppedHeaders: droppedHeaders,
+	}
+	if options.MaxBodyBytes > 0 && len(responseBody) > options.MaxBodyBytes {
+		responseBody = responseBody[:options.MaxBodyBytes]
+		result.BodyTruncated = true
+	}
+	result.Body = string(responseBody)
+	return result, nil
+}
+
+// httpProbeDroppedHeader returns true for the request headers that must not be sent, the hop-by-hop headers and,
+// through the API server proxy, the headers the API server would process as credentials or impersonation
+func httpProbeDroppedHeader(key string, proxy bool) bool {
+	key = http.CanonicalHeaderKey(key)
+	if slices.Contains(httpProbeHopByHopHeaders, key) {
+		return true
+	}
+	return proxy && (key == "Authorization" || key == "Cookie" || strings.HasPrefix(key, "Impersonate-"))
+}
@@ -6,14 +6,22 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/manusa/kubernetes-mcp-server/pkg/version"
 	authv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	metav1beta1 "k8s.io/apimachinery/pkg/apis/meta/v1beta1"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	watchtools "k8s.io/client-go/tools/watch"
 )
 
 const (
@@ -23,6 +31,10 @@ const (
 	AppKubernetesPartOf    = "app.kubernetes.io/part-of"
 )
 
+var scalePollInterval = time.Second
+
+var fieldLabelNotSupported = regexp.MustCompile(`field label not supported: (\S+)`)
+
 type ResourceListOptions struct {
 	metav1.ListOptions
 	AsTable bool
@@ -39,10 +51,16 @@ func (k *Kubernetes) ResourcesList(ctx context.Context, gvk *schema.GroupVersion
 	if isNamespaced && !k.canIUse(ctx, gvr, namespace, "list") && namespace == "" {
 		namespace = k.manager.configuredNamespace()
 	}
+	var ret runtime.Unstructured
 	if options.AsTable {
-		return k.resourcesListAsTable(ctx, gvk, gvr, namespace, options)
+		ret, err = k.resourcesListAsTable(ctx, gvk, gvr, namespace, options)
+	} else {
+		ret, err = k.manager.dynamicClient.Resource(*gvr).Namespace(namespace).List(ctx, options.ListOptions)
 	}
-	return k.manager.dynamicClient.Resource(*gvr).Namespace(namespace).List(ctx, options.ListOptions)
+	if err != nil {
+		return nil, fieldSelectorError(gvk, options.FieldSelector, err)
+	}
+	return ret, nil
 }
 
 func (k *Kubernetes) ResourcesGet(ctx context.Context, gvk *schema.GroupVersionKind, namespace, name string) (*unstructured.Unstructured, error) {
@@ -58,18 +76,106 @@ func (k *Kubernetes) ResourcesGet(ctx context.Context, gvk *schema.GroupVersionK
 	return k.manager.dynamicClient.Resource(*gvr).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
 }
 
-func (k *Kubernetes) ResourcesCreateOrUpdate(ctx context.Context, resource string) ([]*unstructured.Unstructured, error) {
-	separator := regexp.MustCompile(`\r?\n---\r?\n`)
-	resources := separator.Split(resource, -1)
-	var parsedResources []*unstructured.Unstructured
-	for _, r := range resources {
-		var obj unstructured.Unstructured
-		if err := yaml.NewYAMLToJSONDecoder(strings.NewReader(r)).Decode(&obj); err != nil {
+// ResourcesWatch watches the resource with the provided name, or the complete collection if no name is provided.
+// Changes are only reported for events that happen after the watch is started.
+func (k *Kubernetes) ResourcesWatch(ctx context.Context, gvk *schema.GroupVersionKind, namespace, name string) (watch.Interface, error) {
+	gvr, err := k.resourceFor(gvk)
+	if err != nil {
+		return nil, err
+	}
+
+	// If it's a single namespaced resource and namespace wasn't provided, try to use the default configured one
+	if namespaced, nsErr := k.isNamespaced(gvk); nsErr == nil && namespaced && name != "" {
+		namespace = k.NamespaceOrDefault(namespace)
+	}
+	fieldSelector := ""
+	if name != "" {
+		fieldSelector = fields.OneTermEqualSelector("metadata.name", name).String()
+	}
+	resources := k.manager.dynamicClient.Resource(*gvr).Namespace(namespace)
+	// List first to retrieve the current resourceVersion so that existing objects are not reported as changes
+	list, err := resources.List(ctx, metav1.ListOptions{FieldSelector: fieldSelector})
+	if err != nil {
+		return nil, err
+	}
+	return watchtools.NewRetryWatcherWithContext(ctx, list.GetResourceVersion(), &cache.ListWatch{
+		WatchFuncWithContext: func(ctx context.Context, options metav1.ListOptions) (watch.Interface, error) {
+			options.FieldSelector = fieldSelector
+			return resources.Watch(ctx, options)
+		},
+	})
+}
+
+// ResourcesKinds returns the GroupVersionKinds of the preferred API resources served by the cluster that are not denied
+func (k *Kubernetes) ResourcesKinds() ([]schema.GroupVersionKind, error) {
+	discoveryClient, err := k.manager.ToDiscoveryClient()
+	if err != nil {
+		return nil, err
+	}
+	apiResourceLists, err := discoveryClient.ServerPreferredResources()
+	// Discovery might partially fail (e.g. unavailable aggregated APIs), the available resources are still returned
+	if err != nil && len(apiResourceLists) == 0 {
+		return nil, err
+	}
+	ret := make([]schema.GroupVersionKind, 0)
+	for _, apiResourceList := range apiResourceLists {
+		gv, err := schema.ParseGroupVersion(apiResourceList.GroupVersion)
+		if err != nil {
+			continue
+		}
+		for _, apiResource := range apiResourceList.APIResources {
+			// Skip subresources (e.g. pods/log)
+			if strings.Contains(apiResource.Name, "/") {
+				continue
+			}
+			gvk := gv.WithKind(apiResource.Kind)
+			if isAllowed(k.manager.staticConfig, &gvk) {
+				ret = append(ret, gvk)
+			}
+		}
+	}
+	return ret, nil
+}
+
+// ResourcesCreateOrUpdate server-side applies the provided YAML or JSON resources.
+// If dryRun is true, the request is processed by the API server but the resources are not persisted.
+func (k *Kubernetes) ResourcesCreateOrUpdate(ctx context.Context, resource string, dryRun bool) ([]*unstructured.Unstructured, error) {
+	parsedResources, err := parseResources(resource)
+	if err != nil {
+		return nil, err
+	}
+	return k.resourcesCreateOrUpdate(ctx, parsedResources, dryRun)
+}
+
+// ResourceDiff contains the live state of a resource (nil if it doesn't exist) and the state it would have after being applied
+type ResourceDiff struct {
+	Live   *unstructured.Unstructured
+	DryRun *unstructured.Unstructured
+}
+
+// ResourcesDiff server-side applies the provided YAML or JSON resources in dry-run mode and returns them along with their live state
+func (k *Kubernetes) ResourcesDiff(ctx context.Context, resource string) ([]ResourceDiff, error) {
+	parsedResources, err := parseResources(resource)
+	if err != nil {
+		return nil, err
+	}
+	dryRunResources, err := k.resourcesCreateOrUpdate(ctx, parsedResources, true)
+	if err != nil {
+		return nil, err
+	}
+	diffs := make([]ResourceDiff, 0, len(dryRunResources))
+	for _, dryRun := range dryRunResources {
+		gvk := dryRun.GroupVersionKind()
+		live, err := k.ResourcesGet(ctx, &gvk, dryRun.GetNamespace(), dryRun.GetName())
+		if apierrors.IsNotFound(err) {
+			live, err = nil, nil
+		}
+		if err != nil {
 			return nil, err
 		}
-		parsedResources = append(parsedResources, &obj)
+		diffs = append(diffs, ResourceDiff{Live: live, DryRun: dryRun})
 	}
-	return k.resourcesCreateOrUpdate(ctx, parsedResources)
+	return diffs, nil
 }
 
 func (k *Kubernetes) ResourcesDelete(ctx context.Context, gvk *schema.GroupVersionKind, namespace, name string) error {
@@ -85,6 +191,103 @@ func (k *Kubernetes) ResourcesDelete(ctx context.Context, gvk *schema.GroupVersi
 	return k.manager.dynamicClient.Resource(*gvr).Namespace(namespace).Delete(ctx, name, metav1.DeleteOptions{})
 }
 
+// ResourcesPatch patches the resource with the provided name using the provided patch type (JSON patch, JSON merge patch, or strategic merge patch).
+// Strategic merge patches are applied as JSON merge patches for kinds that don't support them (e.g. custom resources).
+func (k *Kubernetes) ResourcesPatch(ctx context.Context, gvk *schema.GroupVersionKind, namespace, name string, patchType types.PatchType, patch []byte) (*unstructured.Unstructured, error) {
+	gvr, err := k.resourceFor(gvk)
+	if err != nil {
+		return nil, err
+	}
+
+	// If it's a namespaced resource and namespace wasn't provided, try to use the default configured one
+	if namespaced, nsErr := k.isNamespaced(gvk); nsErr == nil && namespaced {
+		namespace = k.NamespaceOrDefault(namespace)
+	}
+	// Same as kubectl, strategic merge patch is only supported for the built-in kinds
+	if patchType == types.StrategicMergePatchType && !Scheme.Recognizes(*gvk) {
+		patchType = types.MergePatchType
+	}
+	return k.manager.dynamicClient.Resource(*gvr).Namespace(namespace).Patch(ctx, name, patchType, patch, metav1.PatchOptions{
+		FieldManager: version.BinaryName,
+	})
+}
+
+// ResourceScale contains the replicas of a resource before and after being scaled
+type ResourceScale struct {
+	PreviousReplicas int64
+	Replicas         int64
+	// ReadyReplicas is only set if the scale operation waited for the resource to be ready, for custom resources these are
+	// the replicas reported by the status of the scale subresource (its statusReplicasPath)
+	ReadyReplicas *int64
+}
+
+// ResourcesScale sets the replicas of the resource through its scale subresource.
+// If waitTimeout is greater than 0, it waits until the resource status.readyReplicas matches the desired replicas.
+// Custom resources don't have a well-known ready replicas field, the status.replicas of their scale subresource is
+// checked instead.
+func (k *Kubernetes) ResourcesScale(ctx context.Context, gvk *schema.GroupVersionKind, namespace, name string, replicas int64, waitTimeout time.Duration) (*ResourceScale, error) {
+	gvr, err := k.resourceFor(gvk)
+	if err != nil {
+		return nil, err
+	}
+
+	// If it's a namespaced resource and namespace wasn't provided, try to use the default configured one
+	if namespaced, nsErr := k.isNamespaced(gvk); nsErr == nil && namespaced {
+		namespace = k.NamespaceOrDefault(namespace)
+	}
+	resources := k.manager.dynamicClient.Resource(*gvr).Namespace(namespace)
+	scale, err := resources.Get(ctx, name, metav1.GetOptions{}, "scale")
+	if apierrors.IsNotFound(err) {
+		// The resource exists, but its kind doesn't declare a scale subresource
+		if _, getErr := resources.Get(ctx, name, metav1.GetOptions{}); getErr == nil {
+			return nil, fmt.Errorf("%s %s doesn't support the scale subresource", gvk.Kind, name)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	ret := &ResourceScale{}
+	ret.PreviousReplicas, _, _ = unstructured.NestedInt64(scale.Object, "spec", "replicas")
+	scale, err = resources.Patch(ctx, name, types.MergePatchType, []byte(fmt.Sprintf(`{"spec":{"replicas":%d}}`, replicas)), metav1.PatchOptions{
+		FieldManager: version.BinaryName,
+	}, "scale")
+	if err != nil {
+		return nil, err
+	}
+	ret.Replicas, _, _ = unstructured.NestedInt64(scale.Object, "spec", "replicas")
+	if waitTimeout <= 0 {
+		return ret, nil
+	}
+	// The built-in scalable kinds (Deployment, ReplicaSet, StatefulSet, ReplicationController) report status.readyReplicas
+	builtIn := Scheme.Recognizes(*gvk)
+	ret.ReadyReplicas = new(int64)
+	err = wait.PollUntilContextTimeout(ctx, scalePollInterval, waitTimeout, true, func(ctx context.Context) (bool, error) {
+		if builtIn {
+			resource, err := resources.Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				return false, err
+			}
+			*ret.ReadyReplicas, _, _ = unstructured.NestedInt64(resource.Object, "status", "readyReplicas")
+		} else {
+			scale, err := resources.Get(ctx, name, metav1.GetOptions{}, "scale")
+			if err != nil {
+				return false, err
+			}
+			*ret.ReadyReplicas, _, _ = unstructured.NestedInt64(scale.Object, "status", "replicas")
+		}
+		return *ret.ReadyReplicas == ret.Replicas, nil
+	})
+	if err != nil && builtIn {
+		return ret, fmt.Errorf("%s %s was scaled to %d replicas, but only %d replicas were ready after waiting %s: %w",
+			gvk.Kind, name, ret.Replicas, *ret.ReadyReplicas, waitTimeout, err)
+	}
+	if err != nil {
+		return ret, fmt.Errorf("%s %s was scaled to %d replicas, but its scale subresource reported %d replicas after waiting %s: %w",
+			gvk.Kind, name, ret.Replicas, *ret.ReadyReplicas, waitTimeout, err)
+	}
+	return ret, nil
+}
+
 // resourcesListAsTable retrieves a list of resources in a table format.
 // It's almost identical to the dynamic.DynamicClient implementation, but it uses a specific Accept header to request the table format.
 // dynamic.DynamicClient does not provide a way to set the HTTP header (TODO: create an issue to request this feature)
@@ -132,7 +335,11 @@ func (k *Kubernetes) resourcesListAsTable(ctx context.Context, gvk *schema.Group
 	return &unstructured.Unstructured{Object: unstructuredObject}, err
 }
 
-func (k *Kubernetes) resourcesCreateOrUpdate(ctx context.Context, resources []*unstructured.Unstructured) ([]*unstructured.Unstructured, error) {
+func (k *Kubernetes) resourcesCreateOrUpdate(ctx context.Context, resources []*unstructured.Unstructured, dryRun bool) ([]*unstructured.Unstructured, error) {
+	applyOptions := metav1.ApplyOptions{FieldManager: version.BinaryName}
+	if dryRun {
+		applyOptions.DryRun = []string{metav1.DryRunAll}
+	}
 	for i, obj := range resources {
 		gvk := obj.GroupVersionKind()
 		gvr, rErr := k.resourceFor(&gvk)
@@ -145,20 +352,45 @@ func (k *Kubernetes) resourcesCreateOrUpdate(ctx context.Context, resources []*u
 		if namespaced, nsErr := k.isNamespaced(&gvk); nsErr == nil && namespaced {
 			namespace = k.NamespaceOrDefault(namespace)
 		}
-		resources[i], rErr = k.manager.dynamicClient.Resource(*gvr).Namespace(namespace).Apply(ctx, obj.GetName(), obj, metav1.ApplyOptions{
-			FieldManager: version.BinaryName,
-		})
+		resources[i], rErr = k.manager.dynamicClient.Resource(*gvr).Namespace(namespace).Apply(ctx, obj.GetName(), obj, applyOptions)
 		if rErr != nil {
 			return nil, rErr
 		}
 		// Clear the cache to ensure the next operation is performed on the latest exposed APIs (will change after the CRD creation)
-		if gvk.Kind == "CustomResourceDefinition" {
+		if gvk.Kind == "CustomResourceDefinition" && !dryRun {
 			k.manager.accessControlRESTMapper.Reset()
 		}
 	}
 	return resources, nil
 }
 
+func parseResources(resource string) ([]*unstructured.Unstructured, error) {
+	separator := regexp.MustCompile(`\r?\n---\r?\n`)
+	resources := separator.Split(resource, -1)
+	var parsedResources []*unstructured.Unstructured
+	for _, r := range resources {
+		var obj unstructured.Unstructured
+		if err := yaml.NewYAMLToJSONDecoder(strings.NewReader(r)).Decode(&obj); err != nil {
+			return nil, err
+		}
+		parsedResources = append(parsedResources, &obj)
+	}
+	return parsedResources, nil
+}
+
+// fieldSelectorError translates the API server errors caused by an invalid or unsupported field selector into a clearer message
+func fieldSelectorError(gvk *schema.GroupVersionKind, fieldSelector string, err error) error {
+	if fieldSelector == "" || !apierrors.IsBadRequest(err) {
+		return err
+	}
+	if unsupported := fieldLabelNotSupported.FindStringSubmatch(err.Error()); unsupported != nil {
+		return fmt.Errorf("field selector %s is not supported for %s, the field %s can't be used to filter "+
+			"(only metadata.name, metadata.namespace, and a few kind-specific fields such as status.phase or spec.nodeName for Pods are supported)",
+			fieldSelector, gvk.Kind, unsupported[1])
+	}
+	return fmt.Errorf("invalid field selector %s: %v", fieldSelector, err)
+}
+
 func (k *Kubernetes) resourceFor(gvk *schema.GroupVersionKind) (*schema.GroupVersionResource, error) {
 	m, err := k.manager.accessControlRESTMapper.RESTMapping(schema.GroupKind{Group: gvk.Group, Kind: gvk.Kind}, gvk.Version)
 	if err != nil {
@@ -0,0 +1,191 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/polymorphichelpers"
+	deploymentutil "k8s.io/kubectl/pkg/util/deployment"
+)
+
+// RolloutKinds are the kinds of the apps/v1 workloads that support rollout operations
+var RolloutKinds = []string{"Deployment", "StatefulSet", "DaemonSet"}
+
+// RolloutStatus is the status of the rollout of a workload, the ReplicaSets are only reported for Deployments
+type RolloutStatus struct {
+	Message        string              `json:"message"`
+	Done           bool                `json:"done"`
+	Revision       int64               `json:"revision,omitempty"`
+	Conditions     []interface{}       `json:"conditions,omitempty"`
+	NewReplicaSet  *RolloutReplicaSet  `json:"newReplicaSet,omitempty"`
+	OldReplicaSets []RolloutReplicaSet `json:"oldReplicaSets,omitempty"`
+}
+
+type RolloutReplicaSet struct {
+	Name              string `json:"name"`
+	Revision          int64  `json:"revision"`
+	Replicas          int32  `json:"replicas"`
+	ReadyReplicas     int32  `json:"readyReplicas"`
+	AvailableReplicas int32  `json:"availableReplicas"`
+}
+
+// RolloutStatus returns the status of the rollout of the workload (same as kubectl rollout status without watching).
+// If revision is not 0, the status is checked against that specific revision.
+func (k *Kubernetes) RolloutStatus(ctx context.Context, gvk *schema.GroupVersionKind, namespace, name string, revision int64) (*RolloutStatus, error) {
+	if err := isRolloutKind(gvk); err != nil {
+		return nil, err
+	}
+	workload, err := k.ResourcesGet(ctx, gvk, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	statusViewer, err := polymorphichelpers.StatusViewerFor(gvk.GroupKind())
+	if err != nil {
+		return nil, err
+	}
+	message, done, err := statusViewer.Status(workload, revision)
+	if err != nil {
+		return nil, err
+	}
+	status := &RolloutStatus{Message: strings.TrimSpace(message), Done: done}
+	status.Conditions, _, _ = unstructured.NestedSlice(workload.Object, "status", "conditions")
+	if gvk.Kind != "Deployment" {
+		return status, nil
+	}
+	deployment := &appsv1.Deployment{}
+	if err = runtime.DefaultUnstructuredConverter.FromUnstructured(workload.Object, deployment); err != nil {
+		return nil, err
+	}
+	status.Revision, _ = deploymentutil.Revision(deployment)
+	appsV1, err := k.manager.accessControlClientSet.DeploymentReplicaSets()
+	if err != nil {
+		return nil, err
+	}
+	oldReplicaSets, _, newReplicaSet, err := deploymentutil.GetAllReplicaSets(deployment, appsV1)
+	if err != nil {
+		return nil, err
+	}
+	if newReplicaSet != nil {
+		rs := rolloutReplicaSet(newReplicaSet)
+		status.NewReplicaSet = &rs
+	}
+	for _, oldReplicaSet := range oldReplicaSets {
+		status.OldReplicaSets = append(status.OldReplicaSets, rolloutReplicaSet(oldReplicaSet))
+	}
+	return status, nil
+}
+
+// RolloutHistory returns the revision history of the workload, or the details of a specific revision if revision is not 0
+func (k *Kubernetes) RolloutHistory(ctx context.Context, gvk *schema.GroupVersionKind, namespace, name string, revision int64) (string, error) {
+	if err := isRolloutKind(gvk); err != nil {
+		return "", err
+	}
+	// Ensures the workload exists and is accessible before delegating to the kubectl history viewer
+	workload, err := k.ResourcesGet(ctx, gvk, namespace, name)
+	if err != nil {
+		return "", err
+	}
+	clientset, err := k.manager.accessControlClientSet.Rollouts(gvk)
+	if err != nil {
+		return "", err
+	}
+	historyViewer, err := polymorphichelpers.HistoryViewerFor(gvk.GroupKind(), clientset)
+	if err != nil {
+		return "", err
+	}
+	return historyViewer.ViewHistory(workload.GetNamespace(), workload.GetName(), revision)
+}
+
+// RolloutUndo rolls the workload back to the provided revision, or to the previous one if toRevision is 0
+func (k *Kubernetes) RolloutUndo(ctx context.Context, gvk *schema.GroupVersionKind, namespace, name string, toRevision int64) (string, error) {
+	if err := isRolloutKind(gvk); err != nil {
+		return "", err
+	}
+	workload, err := k.ResourcesGet(ctx, gvk, namespace, name)
+	if err != nil {
+		return "", err
+	}
+	clientset, err := k.manager.accessControlClientSet.Rollouts(gvk)
+	if err != nil {
+		return "", err
+	}
+	rollbacker, err := polymorphichelpers.RollbackerFor(gvk.GroupKind(), clientset)
+	if err != nil {
+		return "", err
+	}
+	return rollbacker.Rollback(workload, nil, toRevision, cmdutil.DryRunNone)
+}
+
+// RolloutRestart restarts the workload pods by updating the kubectl.kubernetes.io/restartedAt pod template annotation
+func (k *Kubernetes) RolloutRestart(ctx context.Context, gvk *schema.GroupVersionKind, namespace, name string) (*unstructured.Unstructured, error) {
+	return k.rolloutPatch(ctx, gvk, namespace, name, polymorphichelpers.ObjectRestarterFn)
+}
+
+// RolloutPause pauses the rollout of the workload, only Deployments can be paused
+func (k *Kubernetes) RolloutPause(ctx context.Context, gvk *schema.GroupVersionKind, namespace, name string) (*unstructured.Unstructured, error) {
+	return k.rolloutPatch(ctx, gvk, namespace, name, polymorphichelpers.ObjectPauserFn)
+}
+
+// RolloutResume resumes the paused rollout of the workload, only Deployments can be resumed
+func (k *Kubernetes) RolloutResume(ctx context.Context, gvk *schema.GroupVersionKind, namespace, name string) (*unstructured.Unstructured, error) {
+	return k.rolloutPatch(ctx, gvk, namespace, name, polymorphichelpers.ObjectResumerFn)
+}
+
+// rolloutPatch applies the changes performed by the kubectl rollout function to the workload as a strategic merge patch
+func (k *Kubernetes) rolloutPatch(ctx context.Context, gvk *schema.GroupVersionKind, namespace, name string, rolloutFn func(runtime.Object) ([]byte, error)) (*unstructured.Unstructured, error) {
+	if err := isRolloutKind(gvk); err != nil {
+		return nil, err
+	}
+	workload, err := k.ResourcesGet(ctx, gvk, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	obj, err := Scheme.New(*gvk)
+	if err != nil {
+		return nil, err
+	}
+	if err = runtime.DefaultUnstructuredConverter.FromUnstructured(workload.Object, obj); err != nil {
+		return nil, err
+	}
+	original, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	modified, err := rolloutFn(obj)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s/%s %v", gvk.Kind, workload.GetNamespace(), name, err)
+	}
+	patch, err := strategicpatch.CreateTwoWayMergePatch(original, modified, obj)
+	if err != nil {
+		return nil, err
+	}
+	return k.ResourcesPatch(ctx, gvk, workload.GetNamespace(), name, types.StrategicMergePatchType, patch)
+}
+
+func isRolloutKind(gvk *schema.GroupVersionKind) error {
+	if gvk.Group != appsv1.GroupName || gvk.Version != appsv1.SchemeGroupVersion.Version || !slices.Contains(RolloutKinds, gvk.Kind) {
+		return fmt.Errorf("rollout is not supported for %s, supported kinds are: %s", gvk.String(), strings.Join(RolloutKinds, ", "))
+	}
+	return nil
+}
+
+func rolloutReplicaSet(rs *appsv1.ReplicaSet) RolloutReplicaSet {
+	revision, _ := deploymentutil.Revision(rs)
+	return RolloutReplicaSet{
+		Name:              rs.Name,
+		Revision:          revision,
+		Replicas:          rs.Status.Replicas,
+		ReadyReplicas:     rs.Status.ReadyReplicas,
+		AvailableReplicas: rs.Status.AvailableReplicas,
+	}
+}
@@ -343,6 +343,30 @@ func (c *mcpContext) callTool(name string, args map[string]interface{}) (*mcp.Ca
 	return c.mcpClient.CallTool(c.ctx, callToolRequest)
 }
 
+// readResource helper function to read a resource by URI
+func (c *mcpContext) readResource(uri string) (*mcp.ReadResourceResult, error) {
+	readResourceRequest := mcp.ReadResourceRequest{}
+	readResourceRequest.Params.URI = uri
+	return c.mcpClient.ReadResource(c.ctx, readResourceRequest)
+}
+
+// getPrompt helper function to get a prompt by name with the provided arguments
+func (c *mcpContext) getPrompt(name string, args map[string]string) (*mcp.GetPromptResult, error) {
+	getPromptRequest := mcp.GetPromptRequest{}
+	getPromptRequest.Params.Name = name
+	getPromptRequest.Params.Arguments = args
+	return c.mcpClient.GetPrompt(c.ctx, getPromptRequest)
+}
+
+// complete helper function to request the completion of an argument for the provided prompt or resource template reference
+func (c *mcpContext) complete(ref any, name, value string, args map[string]string) (*mcp.CompleteResult, error) {
+	completeRequest := mcp.CompleteRequest{}
+	completeRequest.Params.Ref = ref
+	completeRequest.Params.Argument = mcp.CompleteArgument{Name: name, Value: value}
+	completeRequest.Params.Context = mcp.CompleteContext{Arguments: args}
+	return c.mcpClient.Complete(c.ctx, completeRequest)
+}
+
 func restoreAuth(ctx context.Context) {
 	kubernetesAdmin := kubernetes.NewForConfigOrDie(envTest.Config)
 	// Authorization
@@ -0,0 +1,176 @@
+package mcp
+
+import (
+	"context"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/manusa/kubernetes-mcp-server/pkg/kubernetes"
+)
+
+// completionMaxValues is the maximum number of values a completion response may contain (MCP specification)
+const completionMaxValues = 100
+
+type promptCompletionProvider func(ctx context.Context, promptName string, argument mcp.CompleteArgument, completeContext mcp.CompleteContext) (*mcp.Completion, error)
+
+func (f promptCompletionProvider) CompletePromptArgument(ctx context.Context, promptName string, argument mcp.CompleteArgument, completeContext mcp.CompleteContext) (*mcp.Completion, error) {
+	return f(ctx, promptName, argument, completeContext)
+}
+
+type resourceCompletionProvider func(ctx context.Context, uri string, argument mcp.CompleteArgument, completeContext mcp.CompleteContext) (*mcp.Completion, error)
+
+func (f resourceCompletionProvider) CompleteResourceArgument(ctx context.Context, uri string, argument mcp.CompleteArgument, completeContext mcp.CompleteContext) (*mcp.Completion, error) {
+	return f(ctx, uri, argument, completeContext)
+}
+
+func (s *Server) initCompletions() []server.ServerOption {
+	return []server.ServerOption{
+		server.WithCompletions(),
+		server.WithPromptCompletionProvider(promptCompletionProvider(s.completePromptArgument)),
+		server.WithResourceCompletionProvider(resourceCompletionProvider(s.completeResourceArgument)),
+	}
+}
+
+// completePromptArgument suggests values for the arguments of the Pod prompts (the rest of prompts only get namespace suggestions)
+func (s *Server) completePromptArgument(ctx context.Context, promptName string, argument mcp.CompleteArgument, completeContext mcp.CompleteContext) (*mcp.Completion, error) {
+	k := s.k.Derived(ctx)
+	podPrompt := slices.Contains([]string{"debug-pod", "why-pending", "crashloop-analysis"}, promptName)
+	switch {
+	case argument.Name == "namespace":
+		return newCompletion(argument.Value, completeNamespaces(ctx, k)), nil
+	case argument.Name == "name" && podPrompt:
+		return newCompletion(argument.Value, completeNames(ctx, k, &schema.GroupVersionKind{Version: "v1", Kind: "Pod"}, completeContext.Arguments["namespace"])), nil
+	case argument.Name == "container" && podPrompt:
+		return newCompletion(argument.Value, completeContainers(ctx, k, completeContext.Arguments["namespace"], completeContext.Arguments["name"])), nil
+	}
+	return newCompletion(argument.Value, nil), nil
+}
+
+// completeResourceArgument suggests values for the variables of the Kubernetes object resource templates
+func (s *Server) completeResourceArgument(ctx context.Context, _ string, argument mcp.CompleteArgument, completeContext mcp.CompleteContext) (*mcp.Completion, error) {
+	k := s.k.Derived(ctx)
+	// apiVersion might have been provided percent-encoded as required by the URI template
+	apiVersion, err := url.PathUnescape(completeContext.Arguments["apiVersion"])
+	if err != nil {
+		apiVersion = completeContext.Arguments["apiVersion"]
+	}
+	switch argument.Name {
+	case "context":
+		currentContext, err := s.k.CurrentContext()
+		if err != nil {
+			return newCompletion(argument.Value, nil), nil
+		}
+		return newCompletion(argument.Value, []string{currentContext}), nil
+	case "apiVersion":
+		// The values are percent-encoded as required by the URI template, the typed prefix might not be
+		prefix, err := url.PathUnescape(argument.Value)
+		if err != nil {
+			prefix = argument.Value
+		}
+		return newCompletion(url.PathEscape(prefix), completeKinds(k, "", completeContext.Arguments["kind"], func(gvk schema.GroupVersionKind) string {
+			return url.PathEscape(gvk.GroupVersion().String())
+		})), nil
+	case "kind":
+		return newCompletion(argument.Value, completeKinds(k, apiVersion, "", func(gvk schema.GroupVersionKind) string {
+			return gvk.Kind
+		})), nil
+	case "namespace":
+		return newCompletion(argument.Value, completeNamespaces(ctx, k)), nil
+	case "name":
+		gv, err := schema.ParseGroupVersion(apiVersion)
+		if err != nil || completeContext.Arguments["kind"] == "" {
+			return newCompletion(argument.Value, nil), nil
+		}
+		gvk := gv.WithKind(completeContext.Arguments["kind"])
+		return newCompletion(argument.Value, completeNames(ctx, k, &gvk, completeContext.Arguments["namespace"])), nil
+	}
+	return newCompletion(argument.Value, nil), nil
+}
+
+func completeNamespaces(ctx context.Context, k *kubernetes.Kubernetes) []string {
+	ret, err := k.NamespacesList(ctx, kubernetes.ResourceListOptions{})
+	if err != nil {
+		return nil
+	}
+	return objectNames(ret)
+}
+
+func completeNames(ctx context.Context, k *kubernetes.Kubernetes, gvk *schema.GroupVersionKind, namespace string) []string {
+	ret, err := k.ResourcesList(ctx, gvk, k.NamespaceOrDefault(namespace), kubernetes.ResourceListOptions{})
+	if err != nil {
+		return nil
+	}
+	return objectNames(ret)
+}
+
+func completeContainers(ctx context.Context, k *kubernetes.Kubernetes, namespace, name string) []string {
+	if name == "" {
+		return nil
+	}
+	pod, err := k.PodsGet(ctx, namespace, name)
+	if err != nil {
+		return nil
+	}
+	containers, _, _ := unstructured.NestedSlice(pod.Object, "spec", "containers")
+	ret := make([]string, 0, len(containers))
+	for _, container := range containers {
+		if containerName, ok := container.(map[string]interface{})["name"].(string); ok {
+			ret = append(ret, containerName)
+		}
+	}
+	return ret
+}
+
+// completeKinds returns the values extracted with valueOf from the discovered kinds, optionally restricted to the provided apiVersion and kind
+func completeKinds(k *kubernetes.Kubernetes, apiVersion, kind string, valueOf func(gvk schema.GroupVersionKind) string) []string {
+	gvks, err := k.ResourcesKinds()
+	if err != nil {
+		return nil
+	}
+	ret := make([]string, 0)
+	for _, gvk := range gvks {
+		if (apiVersion != "" && gvk.GroupVersion().String() != apiVersion) || (kind != "" && gvk.Kind != kind) {
+			continue
+		}
+		if value := valueOf(gvk); !slices.Contains(ret, value) {
+			ret = append(ret, value)
+		}
+	}
+	return ret
+}
+
+func objectNames(list runtime.Unstructured) []string {
+	ret := make([]string, 0)
+	_ = list.EachListItem(func(obj runtime.Object) error {
+		if accessor, err := meta.Accessor(obj); err == nil {
+			ret = append(ret, accessor.GetName())
+		}
+		return nil
+	})
+	return ret
+}
+
+// newCompletion returns the sorted values starting with prefix, truncated to the maximum allowed size
+func newCompletion(prefix string, values []string) *mcp.Completion {
+	matching := make([]string, 0)
+	for _, value := range values {
+		if strings.HasPrefix(value, prefix) {
+			matching = append(matching, value)
+		}
+	}
+	slices.Sort(matching)
+	completion := &mcp.Completion{Values: matching, Total: len(matching)}
+	if len(matching) > completionMaxValues {
+		completion.Values = matching[:completionMaxValues]
+		completion.HasMore = true
+	}
+	return completion
+}
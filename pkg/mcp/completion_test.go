@@ -0,0 +1,107 @@
+package mcp
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/manusa/kubernetes-mcp-server/pkg/config"
+)
+
+func TestCompletionPrompts(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		c.withEnvTest()
+		debugPod := mcp.PromptReference{Type: "ref/prompt", Name: "debug-pod"}
+		namespaces, err := c.complete(debugPod, "namespace", "ns-", nil)
+		t.Run("complete namespace returns matching namespaces", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("complete failed %v", err)
+			}
+			if !slices.Contains(namespaces.Completion.Values, "ns-1") || !slices.Contains(namespaces.Completion.Values, "ns-2") {
+				t.Fatalf("invalid values, got %v", namespaces.Completion.Values)
+			}
+			if slices.Contains(namespaces.Completion.Values, "default") {
+				t.Fatalf("invalid values, default should not match prefix, got %v", namespaces.Completion.Values)
+			}
+		})
+		names, err := c.complete(debugPod, "name", "", map[string]string{"namespace": "ns-1"})
+		t.Run("complete name returns pods in namespace", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("complete failed %v", err)
+			}
+			if len(names.Completion.Values) != 1 || names.Completion.Values[0] != "a-pod-in-ns-1" {
+				t.Fatalf("invalid values, got %v", names.Completion.Values)
+			}
+		})
+		containers, err := c.complete(debugPod, "container", "", map[string]string{"namespace": "ns-1", "name": "a-pod-in-ns-1"})
+		t.Run("complete container returns pod containers", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("complete failed %v", err)
+			}
+			if len(containers.Completion.Values) != 1 || containers.Completion.Values[0] != "nginx" {
+				t.Fatalf("invalid values, got %v", containers.Completion.Values)
+			}
+		})
+	})
+}
+
+func TestCompletionResourceTemplates(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		c.withEnvTest()
+		object := mcp.ResourceReference{Type: "ref/resource", URI: resourceTemplateObject}
+		apiVersions, err := c.complete(object, "apiVersion", "app", map[string]string{"kind": "Deployment"})
+		t.Run("complete apiVersion returns discovered apiVersions", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("complete failed %v", err)
+			}
+			if len(apiVersions.Completion.Values) != 1 || apiVersions.Completion.Values[0] != "apps%2Fv1" {
+				t.Fatalf("invalid values, got %v", apiVersions.Completion.Values)
+			}
+		})
+		apiVersions, err = c.complete(object, "apiVersion", "apps/", map[string]string{"kind": "Deployment"})
+		t.Run("complete apiVersion with unencoded prefix returns percent-encoded apiVersions", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("complete failed %v", err)
+			}
+			if len(apiVersions.Completion.Values) != 1 || apiVersions.Completion.Values[0] != "apps%2Fv1" {
+				t.Fatalf("invalid values, got %v", apiVersions.Completion.Values)
+			}
+		})
+		kinds, err := c.complete(object, "kind", "Config", map[string]string{"apiVersion": "v1"})
+		t.Run("complete kind returns discovered kinds", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("complete failed %v", err)
+			}
+			if len(kinds.Completion.Values) != 1 || kinds.Completion.Values[0] != "ConfigMap" {
+				t.Fatalf("invalid values, got %v", kinds.Completion.Values)
+			}
+		})
+		names, err := c.complete(object, "name", "", map[string]string{"apiVersion": "v1", "kind": "Pod", "namespace": "ns-2"})
+		t.Run("complete name returns objects of kind", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("complete failed %v", err)
+			}
+			if len(names.Completion.Values) != 1 || names.Completion.Values[0] != "a-pod-in-ns-2" {
+				t.Fatalf("invalid values, got %v", names.Completion.Values)
+			}
+		})
+	})
+}
+
+func TestCompletionDenied(t *testing.T) {
+	deniedResourcesServer := &config.StaticConfig{DeniedResources: []config.GroupVersionKind{{Version: "v1", Kind: "ConfigMap"}}}
+	testCaseWithContext(t, &mcpContext{staticConfig: deniedResourcesServer}, func(c *mcpContext) {
+		c.withEnvTest()
+		object := mcp.ResourceReference{Type: "ref/resource", URI: resourceTemplateObject}
+		kinds, err := c.complete(object, "kind", "Config", map[string]string{"apiVersion": "v1"})
+		t.Run("complete kind excludes denied kinds", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("complete failed %v", err)
+			}
+			if len(kinds.Completion.Values) != 0 {
+				t.Fatalf("invalid values, got %v", kinds.Completion.Values)
+			}
+		})
+	})
+}
@@ -6,22 +6,25 @@ import (
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/manusa/kubernetes-mcp-server/pkg/output"
 )
 
 func (s *Server) initEvents() []server.ServerTool {
 	return []server.ServerTool{
-		{mcp.NewTool("events_list",
+		{Tool: mcp.NewTool("events_list",
 			mcp.WithDescription("List all the Kubernetes events in the current cluster from all namespaces"),
 			mcp.WithString("namespace",
 				mcp.Description("Optional Namespace to retrieve the events from. If not provided, will list events from all namespaces")),
+			mcp.WithString("fieldSelector",
+				mcp.Description("Optional Kubernetes field selector (e.g. 'involvedObject.name=my-pod' or 'type=Warning,reason=BackOff'), use this option when you want to filter the events by field")),
 			// Tool annotations
 			mcp.WithTitleAnnotation("Events: List"),
 			mcp.WithReadOnlyHintAnnotation(true),
 			mcp.WithDestructiveHintAnnotation(false),
 			mcp.WithOpenWorldHintAnnotation(true),
-		), s.eventsList},
+		), Handler: s.eventsList},
 	}
 }
 
@@ -30,7 +33,11 @@ func (s *Server) eventsList(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.
 	if namespace == nil {
 		namespace = ""
 	}
-	eventMap, err := s.k.Derived(ctx).EventsList(ctx, namespace.(string))
+	listOptions := metav1.ListOptions{}
+	if fieldSelector, ok := ctr.GetArguments()["fieldSelector"].(string); ok {
+		listOptions.FieldSelector = fieldSelector
+	}
+	eventMap, err := s.k.Derived(ctx).EventsList(ctx, namespace.(string), listOptions)
 	if err != nil {
 		return NewTextResult("", fmt.Errorf("failed to list events in all namespaces: %v", err)), nil
 	}
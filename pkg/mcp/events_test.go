@@ -92,6 +92,42 @@ func TestEventsList(t *testing.T) {
 				t.Fatalf("unexpected result %v", toolResult.Content[0].(mcp.TextContent).Text)
 			}
 		})
+		_, _ = client.CoreV1().Events("default").Create(c.ctx, &v1.Event{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "a-warning-event",
+			},
+			InvolvedObject: v1.ObjectReference{
+				APIVersion: "v1",
+				Kind:       "Pod",
+				Name:       "another-pod",
+				Namespace:  "default",
+			},
+			Type:    "Warning",
+			Message: "The warning message",
+		}, metav1.CreateOptions{})
+		toolResult, err = c.callTool("events_list", map[string]interface{}{
+			"fieldSelector": "involvedObject.name=another-pod,type=Warning",
+		})
+		t.Run("events_list with field selector returns filtered events OK", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("call tool failed %v", err)
+			}
+			if toolResult.IsError {
+				t.Fatalf("call tool failed")
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "The following events (YAML format) were found:\n"+
+				"- InvolvedObject:\n"+
+				"    Kind: Pod\n"+
+				"    Name: another-pod\n"+
+				"    apiVersion: v1\n"+
+				"  Message: The warning message\n"+
+				"  Namespace: default\n"+
+				"  Reason: \"\"\n"+
+				"  Timestamp: 0001-01-01 00:00:00 +0000 UTC\n"+
+				"  Type: Warning\n" {
+				t.Fatalf("unexpected result %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
 	})
 }
 
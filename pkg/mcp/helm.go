@@ -3,14 +3,19 @@ package mcp
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/manusa/kubernetes-mcp-server/pkg/helm"
 )
 
+const defaultHelmTimeout = 300
+
 func (s *Server) initHelm() []server.ServerTool {
 	return []server.ServerTool{
-		{mcp.NewTool("helm_install",
+		{Tool: mcp.NewTool("helm_install",
 			mcp.WithDescription("Install a Helm chart in the current or provided namespace"),
 			mcp.WithString("chart", mcp.Description("Chart reference to install (for example: stable/grafana, oci://ghcr.io/nginxinc/charts/nginx-ingress)"), mcp.Required()),
 			mcp.WithObject("values", mcp.Description("Values to pass to the Helm chart (Optional)")),
@@ -20,10 +25,25 @@ func (s *Server) initHelm() []server.ServerTool {
 			mcp.WithTitleAnnotation("Helm: Install"),
 			mcp.WithReadOnlyHintAnnotation(false),
 			mcp.WithDestructiveHintAnnotation(false),
-			mcp.WithIdempotentHintAnnotation(false), // TODO: consider replacing implementation with equivalent to: helm upgrade --install
+			mcp.WithIdempotentHintAnnotation(false), // helm_upgrade with install=true is the idempotent equivalent (helm upgrade --install)
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.helmInstall},
+		{Tool: mcp.NewTool("helm_lint",
+			mcp.WithDescription("Examine a Helm chart for possible issues with the provided values, without contacting the cluster (equivalent to helm lint)"),
+			mcp.WithString("chart", mcp.Description("Chart reference to lint, a local chart directory or packaged chart (.tgz) path, or a remote chart (for example: ./my-chart, ./my-chart-1.0.0.tgz, oci://ghcr.io/nginxinc/charts/nginx-ingress)"), mcp.Required()),
+			mcp.WithString("namespace", mcp.Description("Namespace used to render the chart (Optional, current namespace if not provided)")),
+			mcp.WithString("version", mcp.Description("Version constraint of the chart to lint, e.g. 1.2.3 or ^1.2 (Optional, latest version if not provided, remote charts only)")),
+			mcp.WithObject("values", mcp.Description("Values to pass to the Helm chart, they take precedence over values_yaml (Optional)")),
+			mcp.WithString("values_yaml", mcp.Description("Values to pass to the Helm chart as a YAML document, e.g. the content of a values.yaml file (Optional)")),
+			mcp.WithBoolean("strict", mcp.Description("If true, lint warnings fail the chart (Optional, false by default)")),
+			// Tool annotations
+			mcp.WithTitleAnnotation("Helm: Lint"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithIdempotentHintAnnotation(true),
 			mcp.WithOpenWorldHintAnnotation(true),
-		), s.helmInstall},
-		{mcp.NewTool("helm_list",
+		), Handler: s.helmLint},
+		{Tool: mcp.NewTool("helm_list",
 			mcp.WithDescription("List all the Helm releases in the current or provided namespace (or in all namespaces if specified)"),
 			mcp.WithString("namespace", mcp.Description("Namespace to list Helm releases from (Optional, all namespaces if not provided)")),
 			mcp.WithBoolean("all_namespaces", mcp.Description("If true, lists all Helm releases in all namespaces ignoring the namespace argument (Optional)")),
@@ -32,8 +52,25 @@ func (s *Server) initHelm() []server.ServerTool {
 			mcp.WithReadOnlyHintAnnotation(true),
 			mcp.WithDestructiveHintAnnotation(false),
 			mcp.WithOpenWorldHintAnnotation(true),
-		), s.helmList},
-		{mcp.NewTool("helm_uninstall",
+		), Handler: s.helmList},
+		{Tool: mcp.NewTool("helm_template",
+			mcp.WithDescription("Render the manifests of a Helm chart locally with the provided values, without contacting the cluster (equivalent to helm template)"),
+			mcp.WithString("chart", mcp.Description("Chart reference to render, a local chart directory or packaged chart (.tgz) path, or a remote chart (for example: ./my-chart, ./my-chart-1.0.0.tgz, oci://ghcr.io/nginxinc/charts/nginx-ingress)"), mcp.Required()),
+			mcp.WithString("name", mcp.Description("Name of the Helm release used to render the chart (Optional, release-name if not provided)")),
+			mcp.WithString("namespace", mcp.Description("Namespace used to render the chart (Optional, current namespace if not provided)")),
+			mcp.WithString("version", mcp.Description("Version constraint of the chart to render, e.g. 1.2.3 or ^1.2 (Optional, latest version if not provided, remote charts only)")),
+			mcp.WithObject("values", mcp.Description("Values to pass to the Helm chart, they take precedence over values_yaml (Optional)")),
+			mcp.WithString("values_yaml", mcp.Description("Values to pass to the Helm chart as a YAML document, e.g. the content of a values.yaml file (Optional)")),
+			mcp.WithBoolean("include_crds", mcp.Description("If true, includes the CRDs of the chart in the rendered manifests (Optional, false by default)")),
+			mcp.WithString("kube_version", mcp.Description("Kubernetes version used for Capabilities.KubeVersion, e.g. 1.33.0 (Optional)")),
+			// Tool annotations
+			mcp.WithTitleAnnotation("Helm: Template"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithIdempotentHintAnnotation(true),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.helmTemplate},
+		{Tool: mcp.NewTool("helm_uninstall",
 			mcp.WithDescription("Uninstall a Helm release in the current or provided namespace"),
 			mcp.WithString("name", mcp.Description("Name of the Helm release to uninstall"), mcp.Required()),
 			mcp.WithString("namespace", mcp.Description("Namespace to uninstall the Helm release from (Optional, current namespace if not provided)")),
@@ -43,7 +80,99 @@ func (s *Server) initHelm() []server.ServerTool {
 			mcp.WithDestructiveHintAnnotation(true),
 			mcp.WithIdempotentHintAnnotation(true),
 			mcp.WithOpenWorldHintAnnotation(true),
-		), s.helmUninstall},
+		), Handler: s.helmUninstall},
+		{Tool: mcp.NewTool("helm_diff",
+			mcp.WithDescription("Preview the changes of a Helm upgrade (see helm_upgrade) without applying them, the target chart and values are rendered with a server-side dry-run and "+
+				"compared to the manifest of the deployed release (the last revision if none is deployed), returning the unified diff of each added, removed, and changed resource "+
+				"(the values of the Secrets are redacted unless show_secrets is true)"),
+			mcp.WithString("chart", mcp.Description("Chart reference to upgrade the release to (for example: stable/grafana, oci://ghcr.io/nginxinc/charts/nginx-ingress)"), mcp.Required()),
+			mcp.WithString("name", mcp.Description("Name of the Helm release to upgrade"), mcp.Required()),
+			mcp.WithString("namespace", mcp.Description("Namespace of the Helm release (Optional, current namespace if not provided)")),
+			mcp.WithString("version", mcp.Description("Version constraint of the chart to upgrade to, e.g. 1.2.3 or ^1.2 (Optional, latest version if not provided)")),
+			mcp.WithObject("values", mcp.Description("Values to pass to the Helm chart, they take precedence over values_yaml (Optional)")),
+			mcp.WithString("values_yaml", mcp.Description("Values to pass to the Helm chart as a YAML document, e.g. the content of a values.yaml file (Optional)")),
+			mcp.WithBoolean("install", mcp.Description("If true, previews the installation of the release if it doesn't exist or was uninstalled (Optional, false by default)")),
+			mcp.WithBoolean("reuse_values", mcp.Description("If true, merges the values of the current release with the provided values (Optional, can't be used with reset_values)")),
+			mcp.WithBoolean("reset_values", mcp.Description("If true, resets the values to the chart defaults before applying the provided values (Optional, can't be used with reuse_values)")),
+			mcp.WithBoolean("show_secrets", mcp.Description("If true, the values of the Secrets are not redacted (Optional, false by default, refused if Secrets are denied or the server is read-only)")),
+			// Tool annotations
+			mcp.WithTitleAnnotation("Helm: Diff"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithIdempotentHintAnnotation(true),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.helmDiff},
+		{Tool: mcp.NewTool("helm_get",
+			mcp.WithDescription("Get the values, rendered manifest, notes, or hooks of a Helm release (revision) in the current or provided namespace, "+
+				"the values of the Secrets in the manifest and hooks are redacted unless show_secrets is true"),
+			mcp.WithString("name", mcp.Description("Name of the Helm release"), mcp.Required()),
+			mcp.WithString("mode", mcp.Description("What to get from the Helm release: values (user-supplied, or all the computed values if all_values is true), manifest, notes, or hooks"),
+				mcp.Enum(helm.GetModes...), mcp.Required()),
+			mcp.WithString("namespace", mcp.Description("Namespace of the Helm release (Optional, current namespace if not provided)")),
+			mcp.WithNumber("revision", mcp.Description("Revision of the Helm release (Optional, latest revision if not provided)")),
+			mcp.WithBoolean("all_values", mcp.Description("If true, returns all the computed values instead of the user-supplied ones (Optional, values mode only)")),
+			mcp.WithBoolean("show_secrets", mcp.Description("If true, the values of the Secrets in the manifest and hooks are not redacted (Optional, false by default, refused if Secrets are denied or the server is read-only)")),
+			// Tool annotations
+			mcp.WithTitleAnnotation("Helm: Get"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.helmGet},
+		{Tool: mcp.NewTool("helm_history",
+			mcp.WithDescription("List the revisions of a Helm release in the current or provided namespace, including their status, chart version, and description"),
+			mcp.WithString("name", mcp.Description("Name of the Helm release"), mcp.Required()),
+			mcp.WithString("namespace", mcp.Description("Namespace of the Helm release (Optional, current namespace if not provided)")),
+			mcp.WithNumber("max", mcp.Description("Maximum number of revisions to return, the most recent ones (Optional, all revisions if not provided)")),
+			// Tool annotations
+			mcp.WithTitleAnnotation("Helm: History"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.helmHistory},
+		{Tool: mcp.NewTool("helm_rollback",
+			mcp.WithDescription("Roll back a Helm release in the current or provided namespace to a previous revision, a new revision with the configuration of the target revision is created"),
+			mcp.WithString("name", mcp.Description("Name of the Helm release to roll back"), mcp.Required()),
+			mcp.WithString("namespace", mcp.Description("Namespace of the Helm release (Optional, current namespace if not provided)")),
+			mcp.WithNumber("revision", mcp.Description("Revision to roll back to (Optional, previous revision if not provided)")),
+			mcp.WithBoolean("wait", mcp.Description("If true, waits until the resources of the release are ready (Optional, true by default)")),
+			mcp.WithNumber("timeout", mcp.Description(fmt.Sprintf("Maximum number of seconds to wait for the resources to be ready (Optional, %d seconds if not provided)", defaultHelmTimeout))),
+			// Tool annotations
+			mcp.WithTitleAnnotation("Helm: Rollback"),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(true),
+			mcp.WithIdempotentHintAnnotation(false),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.helmRollback},
+		{Tool: mcp.NewTool("helm_status",
+			mcp.WithDescription("Get the status of a Helm release in the current or provided namespace, including the release information, notes, and the status of the resources deployed by the release"),
+			mcp.WithString("name", mcp.Description("Name of the Helm release"), mcp.Required()),
+			mcp.WithString("namespace", mcp.Description("Namespace of the Helm release (Optional, current namespace if not provided)")),
+			mcp.WithNumber("revision", mcp.Description("Revision of the Helm release (Optional, latest revision if not provided)")),
+			// Tool annotations
+			mcp.WithTitleAnnotation("Helm: Status"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.helmStatus},
+		{Tool: mcp.NewTool("helm_upgrade",
+			mcp.WithDescription("Upgrade a Helm release in the current or provided namespace to a new chart version or with new values "+
+				"(or install it if install is true and the release doesn't exist, equivalent to helm upgrade --install)"),
+			mcp.WithString("chart", mcp.Description("Chart reference to upgrade the release to (for example: stable/grafana, oci://ghcr.io/nginxinc/charts/nginx-ingress)"), mcp.Required()),
+			mcp.WithString("name", mcp.Description("Name of the Helm release to upgrade"), mcp.Required()),
+			mcp.WithString("namespace", mcp.Description("Namespace of the Helm release (Optional, current namespace if not provided)")),
+			mcp.WithString("version", mcp.Description("Version constraint of the chart to upgrade to, e.g. 1.2.3 or ^1.2 (Optional, latest version if not provided)")),
+			mcp.WithObject("values", mcp.Description("Values to pass to the Helm chart, they take precedence over values_yaml (Optional)")),
+			mcp.WithString("values_yaml", mcp.Description("Values to pass to the Helm chart as a YAML document, e.g. the content of a values.yaml file (Optional)")),
+			mcp.WithBoolean("install", mcp.Description("If true, installs the release if it doesn't exist or was uninstalled (Optional, false by default)")),
+			mcp.WithBoolean("reuse_values", mcp.Description("If true, merges the values of the current release with the provided values (Optional, can't be used with reset_values)")),
+			mcp.WithBoolean("reset_values", mcp.Description("If true, resets the values to the chart defaults before applying the provided values (Optional, can't be used with reuse_values)")),
+			// Tool annotations
+			mcp.WithTitleAnnotation("Helm: Upgrade"),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(true),
+			mcp.WithIdempotentHintAnnotation(false),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.helmUpgrade},
 	}
 }
 
@@ -104,3 +233,204 @@ func (s *Server) helmUninstall(ctx context.Context, ctr mcp.CallToolRequest) (*m
 	}
 	return NewTextResult(ret, err), nil
 }
+
+func (s *Server) helmDiff(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var chart, name string
+	ok := false
+	if chart, ok = ctr.GetArguments()["chart"].(string); !ok {
+		return NewTextResult("", fmt.Errorf("failed to diff helm release, missing argument chart")), nil
+	}
+	if name, ok = ctr.GetArguments()["name"].(string); !ok || name == "" {
+		return NewTextResult("", fmt.Errorf("failed to diff helm release, missing argument name")), nil
+	}
+	namespace := ""
+	if v, ok := ctr.GetArguments()["namespace"].(string); ok {
+		namespace = v
+	}
+	options := helm.DiffOptions{}
+	options.Version, _ = ctr.GetArguments()["version"].(string)
+	options.Values, _ = ctr.GetArguments()["values"].(map[string]interface{})
+	options.ValuesYaml, _ = ctr.GetArguments()["values_yaml"].(string)
+	options.Install, _ = ctr.GetArguments()["install"].(bool)
+	options.ReuseValues, _ = ctr.GetArguments()["reuse_values"].(bool)
+	options.ResetValues, _ = ctr.GetArguments()["reset_values"].(bool)
+	options.ShowSecrets, _ = ctr.GetArguments()["show_secrets"].(bool)
+	ret, err := s.k.Derived(ctx).NewHelm().Diff(ctx, chart, name, namespace, options)
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to diff helm release '%s': %w", name, err)), nil
+	}
+	return NewTextResult(ret, err), nil
+}
+
+func (s *Server) helmGet(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var name, mode string
+	ok := false
+	if name, ok = ctr.GetArguments()["name"].(string); !ok {
+		return NewTextResult("", fmt.Errorf("failed to get helm release, missing argument name")), nil
+	}
+	if mode, ok = ctr.GetArguments()["mode"].(string); !ok {
+		return NewTextResult("", fmt.Errorf("failed to get helm release, missing argument mode")), nil
+	}
+	namespace := ""
+	if v, ok := ctr.GetArguments()["namespace"].(string); ok {
+		namespace = v
+	}
+	options := helm.GetOptions{}
+	if v, ok := ctr.GetArguments()["revision"].(float64); ok {
+		options.Revision = int(v)
+	}
+	options.AllValues, _ = ctr.GetArguments()["all_values"].(bool)
+	options.ShowSecrets, _ = ctr.GetArguments()["show_secrets"].(bool)
+	ret, err := s.k.Derived(ctx).NewHelm().Get(name, namespace, mode, options)
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to get helm release '%s' %s: %w", name, mode, err)), nil
+	}
+	return NewTextResult(ret, err), nil
+}
+
+func (s *Server) helmHistory(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var name string
+	ok := false
+	if name, ok = ctr.GetArguments()["name"].(string); !ok {
+		return NewTextResult("", fmt.Errorf("failed to get helm release history, missing argument name")), nil
+	}
+	namespace := ""
+	if v, ok := ctr.GetArguments()["namespace"].(string); ok {
+		namespace = v
+	}
+	max := 0
+	if v, ok := ctr.GetArguments()["max"].(float64); ok {
+		max = int(v)
+	}
+	ret, err := s.k.Derived(ctx).NewHelm().History(name, namespace, max)
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to get helm release '%s' history: %w", name, err)), nil
+	}
+	return NewTextResult(ret, err), nil
+}
+
+func (s *Server) helmRollback(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var name string
+	ok := false
+	if name, ok = ctr.GetArguments()["name"].(string); !ok {
+		return NewTextResult("", fmt.Errorf("failed to roll back helm release, missing argument name")), nil
+	}
+	namespace := ""
+	if v, ok := ctr.GetArguments()["namespace"].(string); ok {
+		namespace = v
+	}
+	revision := 0
+	if v, ok := ctr.GetArguments()["revision"].(float64); ok {
+		revision = int(v)
+	}
+	wait := true
+	if v, ok := ctr.GetArguments()["wait"].(bool); ok {
+		wait = v
+	}
+	timeout := defaultHelmTimeout
+	if v, ok := ctr.GetArguments()["timeout"].(float64); ok {
+		timeout = int(v)
+	}
+	ret, err := s.k.Derived(ctx).NewHelm().Rollback(name, namespace, revision, wait, time.Duration(timeout)*time.Second)
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to roll back helm release '%s': %w", name, err)), nil
+	}
+	return NewTextResult(ret, err), nil
+}
+
+func (s *Server) helmStatus(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var name string
+	ok := false
+	if name, ok = ctr.GetArguments()["name"].(string); !ok {
+		return NewTextResult("", fmt.Errorf("failed to get helm release status, missing argument name")), nil
+	}
+	namespace := ""
+	if v, ok := ctr.GetArguments()["namespace"].(string); ok {
+		namespace = v
+	}
+	revision := 0
+	if v, ok := ctr.GetArguments()["revision"].(float64); ok {
+		revision = int(v)
+	}
+	ret, err := s.k.Derived(ctx).NewHelm().Status(name, namespace, revision)
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to get helm release '%s' status: %w", name, err)), nil
+	}
+	return NewTextResult(ret, err), nil
+}
+
+func (s *Server) helmTemplate(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var chart string
+	ok := false
+	if chart, ok = ctr.GetArguments()["chart"].(string); !ok {
+		return NewTextResult("", fmt.Errorf("failed to render helm chart, missing argument chart")), nil
+	}
+	name := ""
+	if v, ok := ctr.GetArguments()["name"].(string); ok {
+		name = v
+	}
+	namespace := ""
+	if v, ok := ctr.GetArguments()["namespace"].(string); ok {
+		namespace = v
+	}
+	options := helm.TemplateOptions{}
+	options.Version, _ = ctr.GetArguments()["version"].(string)
+	options.Values, _ = ctr.GetArguments()["values"].(map[string]interface{})
+	options.ValuesYaml, _ = ctr.GetArguments()["values_yaml"].(string)
+	options.IncludeCRDs, _ = ctr.GetArguments()["include_crds"].(bool)
+	options.KubeVersion, _ = ctr.GetArguments()["kube_version"].(string)
+	ret, err := s.k.Derived(ctx).NewHelm().Template(ctx, chart, name, namespace, options)
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to render helm chart '%s': %w", chart, err)), nil
+	}
+	return NewTextResult(ret, err), nil
+}
+
+func (s *Server) helmLint(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var chart string
+	ok := false
+	if chart, ok = ctr.GetArguments()["chart"].(string); !ok {
+		return NewTextResult("", fmt.Errorf("failed to lint helm chart, missing argument chart")), nil
+	}
+	namespace := ""
+	if v, ok := ctr.GetArguments()["namespace"].(string); ok {
+		namespace = v
+	}
+	options := helm.LintOptions{}
+	options.Version, _ = ctr.GetArguments()["version"].(string)
+	options.Values, _ = ctr.GetArguments()["values"].(map[string]interface{})
+	options.ValuesYaml, _ = ctr.GetArguments()["values_yaml"].(string)
+	options.Strict, _ = ctr.GetArguments()["strict"].(bool)
+	ret, err := s.k.Derived(ctx).NewHelm().Lint(chart, namespace, options)
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to lint helm chart '%s': %w", chart, err)), nil
+	}
+	return NewTextResult(ret, err), nil
+}
+
+func (s *Server) helmUpgrade(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var chart, name string
+	ok := false
+	if chart, ok = ctr.GetArguments()["chart"].(string); !ok {
+		return NewTextResult("", fmt.Errorf("failed to upgrade helm release, missing argument chart")), nil
+	}
+	if name, ok = ctr.GetArguments()["name"].(string); !ok || name == "" {
+		return NewTextResult("", fmt.Errorf("failed to upgrade helm release, missing argument name")), nil
+	}
+	namespace := ""
+	if v, ok := ctr.GetArguments()["namespace"].(string); ok {
+		namespace = v
+	}
+	options := helm.UpgradeOptions{}
+	options.Version, _ = ctr.GetArguments()["version"].(string)
+	options.Values, _ = ctr.GetArguments()["values"].(map[string]interface{})
+	options.ValuesYaml, _ = ctr.GetArguments()["values_yaml"].(string)
+	options.Install, _ = ctr.GetArguments()["install"].(bool)
+	options.ReuseValues, _ = ctr.GetArguments()["reuse_values"].(bool)
+	options.ResetValues, _ = ctr.GetArguments()["reset_values"].(bool)
+	ret, err := s.k.Derived(ctx).NewHelm().Upgrade(ctx, chart, name, namespace, options)
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to upgrade helm release '%s': %w", name, err)), nil
+	}
+	return NewTextResult(ret, err), nil
+}
@@ -254,6 +254,630 @@ func TestHelmUninstallDenied(t *testing.T) {
 	})
 }
 
+func TestHelmTemplate(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		mockServer := NewMockServer()
+		defer mockServer.Close()
+		c.withKubeConfig(mockServer.config)
+		_, file, _, _ := runtime.Caller(0)
+		chartPath := filepath.Join(filepath.Dir(file), "testdata", "helm-chart-configmap")
+		toolResult, err := c.callTool("helm_template", map[string]interface{}{
+			"chart":       chartPath,
+			"name":        "release-to-render",
+			"namespace":   "ns-1",
+			"values":      map[string]interface{}{"key": "inline"},
+			"values_yaml": "greeting: hi\nkey: yaml",
+		})
+		t.Run("helm_template with local chart and values, returns rendered manifests", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("call tool failed %v", err)
+			}
+			if toolResult.IsError {
+				t.Fatalf("call tool failed %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+			var decoded map[string]interface{}
+			manifest := strings.TrimPrefix(toolResult.Content[0].(mcp.TextContent).Text, "---\n")
+			err = yaml.Unmarshal([]byte(manifest), &decoded)
+			if err != nil {
+				t.Fatalf("invalid tool result content %v", err)
+			}
+			if decoded["metadata"].(map[string]interface{})["name"] != "release-to-render-configmap" {
+				t.Fatalf("invalid rendered name, got %v", decoded["metadata"])
+			}
+			if decoded["metadata"].(map[string]interface{})["namespace"] != "ns-1" {
+				t.Fatalf("invalid rendered namespace, got %v", decoded["metadata"])
+			}
+			if decoded["data"].(map[string]interface{})["greeting"] != "hi" || decoded["data"].(map[string]interface{})["key"] != "inline" {
+				t.Fatalf("invalid rendered data, got %v", decoded["data"])
+			}
+		})
+		toolResult, _ = c.callTool("helm_template", map[string]interface{}{"chart": chartPath})
+		t.Run("helm_template with missing required values, returns error", func(t *testing.T) {
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			if !strings.Contains(toolResult.Content[0].(mcp.TextContent).Text, "key is required") {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+	})
+}
+
+func TestHelmLint(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		mockServer := NewMockServer()
+		defer mockServer.Close()
+		c.withKubeConfig(mockServer.config)
+		_, file, _, _ := runtime.Caller(0)
+		chartPath := filepath.Join(filepath.Dir(file), "testdata", "helm-chart-configmap")
+		toolResult, err := c.callTool("helm_lint", map[string]interface{}{
+			"chart":  chartPath,
+			"values": map[string]interface{}{"key": "value"},
+		})
+		t.Run("helm_lint with valid chart, returns no failures", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("call tool failed %v", err)
+			}
+			if toolResult.IsError {
+				t.Fatalf("call tool failed %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+			if !strings.HasSuffix(toolResult.Content[0].(mcp.TextContent).Text, "1 chart(s) linted, 0 chart(s) failed") {
+				t.Fatalf("unexpected result %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		toolResult, err = c.callTool("helm_lint", map[string]interface{}{
+			"chart":       chartPath,
+			"values_yaml": "greeting: 1",
+		})
+		t.Run("helm_lint with values not matching the schema, returns failures", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("call tool failed %v", err)
+			}
+			if toolResult.IsError {
+				t.Fatalf("call tool failed %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+			if !strings.Contains(toolResult.Content[0].(mcp.TextContent).Text, "[ERROR] values.yaml: ") ||
+				!strings.Contains(toolResult.Content[0].(mcp.TextContent).Text, "greeting") {
+				t.Fatalf("expected lint error, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+			if !strings.HasSuffix(toolResult.Content[0].(mcp.TextContent).Text, "1 chart(s) linted, 1 chart(s) failed") {
+				t.Fatalf("unexpected result %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		toolResult, _ = c.callTool("helm_lint", map[string]interface{}{"chart": filepath.Join(chartPath, "non-existent")})
+		t.Run("helm_lint with non-existent chart, returns error", func(t *testing.T) {
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+		})
+	})
+}
+
+func TestHelmUpgrade(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		c.withEnvTest()
+		kc := c.newKubernetesClient()
+		clearHelmReleases(c.ctx, kc)
+		_, file, _, _ := runtime.Caller(0)
+		chartPath := filepath.Join(filepath.Dir(file), "testdata", "helm-chart-no-op")
+		toolResult, _ := c.callTool("helm_upgrade", map[string]interface{}{
+			"chart": chartPath,
+			"name":  "release-to-upgrade",
+		})
+		t.Run("helm_upgrade with non-existent release and no install, returns error", func(t *testing.T) {
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			expectedMessage := "failed to upgrade helm release 'release-to-upgrade': release release-to-upgrade not found, set install to true to install it"
+			if toolResult.Content[0].(mcp.TextContent).Text != expectedMessage {
+				t.Fatalf("invalid error message, expected %s, got %v", expectedMessage, toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		toolResult, err := c.callTool("helm_upgrade", map[string]interface{}{
+			"chart":   chartPath,
+			"name":    "release-to-upgrade",
+			"install": true,
+		})
+		t.Run("helm_upgrade with non-existent release and install, returns installed release", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("call tool failed %v", err)
+			}
+			if toolResult.IsError {
+				t.Fatalf("call tool failed %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+			var decoded []map[string]interface{}
+			err = yaml.Unmarshal([]byte(toolResult.Content[0].(mcp.TextContent).Text), &decoded)
+			if err != nil {
+				t.Fatalf("invalid tool result content %v", err)
+			}
+			if decoded[0]["name"] != "release-to-upgrade" {
+				t.Fatalf("invalid helm upgrade name, expected release-to-upgrade, got %v", decoded[0]["name"])
+			}
+			if decoded[0]["revision"] != float64(1) || decoded[0]["previousRevision"] != float64(0) {
+				t.Fatalf("invalid helm upgrade revisions, expected 0 -> 1, got %v -> %v", decoded[0]["previousRevision"], decoded[0]["revision"])
+			}
+			if decoded[0]["status"] != "deployed" {
+				t.Fatalf("invalid helm upgrade status, expected deployed, got %v", decoded[0]["status"])
+			}
+		})
+		toolResult, err = c.callTool("helm_upgrade", map[string]interface{}{
+			"chart":        chartPath,
+			"name":         "release-to-upgrade",
+			"values":       map[string]interface{}{"key": "inline"},
+			"values_yaml":  "key: yaml\nother: yaml",
+			"reuse_values": true,
+		})
+		t.Run("helm_upgrade with existing release, returns upgraded release", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("call tool failed %v", err)
+			}
+			if toolResult.IsError {
+				t.Fatalf("call tool failed %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+			var decoded []map[string]interface{}
+			err = yaml.Unmarshal([]byte(toolResult.Content[0].(mcp.TextContent).Text), &decoded)
+			if err != nil {
+				t.Fatalf("invalid tool result content %v", err)
+			}
+			if decoded[0]["revision"] != float64(2) || decoded[0]["previousRevision"] != float64(1) {
+				t.Fatalf("invalid helm upgrade revisions, expected 1 -> 2, got %v -> %v", decoded[0]["previousRevision"], decoded[0]["revision"])
+			}
+			if decoded[0]["status"] != "deployed" {
+				t.Fatalf("invalid helm upgrade status, expected deployed, got %v", decoded[0]["status"])
+			}
+		})
+		toolResult, _ = c.callTool("helm_upgrade", map[string]interface{}{
+			"chart":        chartPath,
+			"name":         "release-to-upgrade",
+			"reuse_values": true,
+			"reset_values": true,
+		})
+		t.Run("helm_upgrade with reuse_values and reset_values, returns error", func(t *testing.T) {
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			if !strings.HasSuffix(toolResult.Content[0].(mcp.TextContent).Text, "reuse_values and reset_values are mutually exclusive") {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+	})
+}
+
+func TestHelmUpgradeUninstalled(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		c.withEnvTest()
+		kc := c.newKubernetesClient()
+		clearHelmReleases(c.ctx, kc)
+		_, _ = kc.CoreV1().Secrets("default").Create(c.ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "sh.helm.release.v1.release-uninstalled.v1",
+				Labels: map[string]string{"owner": "helm", "name": "release-uninstalled", "version": "1", "status": "uninstalled"},
+			},
+			Data: map[string][]byte{
+				"release": []byte(base64.StdEncoding.EncodeToString([]byte("{" +
+					"\"name\":\"release-uninstalled\"," +
+					"\"namespace\":\"default\"," +
+					"\"version\":1," +
+					"\"info\":{\"status\":\"uninstalled\",\"description\":\"Uninstallation complete\"}" +
+					"}"))),
+			},
+		}, metav1.CreateOptions{})
+		_, file, _, _ := runtime.Caller(0)
+		chartPath := filepath.Join(filepath.Dir(file), "testdata", "helm-chart-no-op")
+		toolResult, _ := c.callTool("helm_upgrade", map[string]interface{}{
+			"chart": chartPath,
+			"name":  "release-uninstalled",
+		})
+		t.Run("helm_upgrade with uninstalled release and no install, returns error", func(t *testing.T) {
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			expectedMessage := "failed to upgrade helm release 'release-uninstalled': release release-uninstalled is uninstalled, set install to true to install it"
+			if toolResult.Content[0].(mcp.TextContent).Text != expectedMessage {
+				t.Fatalf("invalid error message, expected %s, got %v", expectedMessage, toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		toolResult, err := c.callTool("helm_upgrade", map[string]interface{}{
+			"chart":   chartPath,
+			"name":    "release-uninstalled",
+			"install": true,
+		})
+		t.Run("helm_upgrade with uninstalled release and install, returns reinstalled release", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("call tool failed %v", err)
+			}
+			if toolResult.IsError {
+				t.Fatalf("call tool failed %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+			var decoded []map[string]interface{}
+			err = yaml.Unmarshal([]byte(toolResult.Content[0].(mcp.TextContent).Text), &decoded)
+			if err != nil {
+				t.Fatalf("invalid tool result content %v", err)
+			}
+			if decoded[0]["revision"] != float64(2) || decoded[0]["previousRevision"] != float64(0) {
+				t.Fatalf("invalid helm upgrade revisions, expected 0 -> 2, got %v -> %v", decoded[0]["previousRevision"], decoded[0]["revision"])
+			}
+			if decoded[0]["status"] != "deployed" {
+				t.Fatalf("invalid helm upgrade status, expected deployed, got %v", decoded[0]["status"])
+			}
+		})
+	})
+}
+
+func TestHelmDiff(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		c.withEnvTest()
+		kc := c.newKubernetesClient()
+		clearHelmReleases(c.ctx, kc)
+		_, file, _, _ := runtime.Caller(0)
+		chartPath := filepath.Join(filepath.Dir(file), "testdata", "helm-chart-secret")
+		toolResult, _ := c.callTool("helm_diff", map[string]interface{}{"chart": chartPath, "name": "release-to-diff"})
+		t.Run("helm_diff with non-existent release and no install, returns error", func(t *testing.T) {
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+		})
+		toolResult, err := c.callTool("helm_diff", map[string]interface{}{"chart": chartPath, "name": "release-to-diff", "install": true})
+		t.Run("helm_diff with non-existent release and install, returns added resources", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("call tool failed %v", err)
+			}
+			if toolResult.IsError {
+				t.Fatalf("call tool failed %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+			diff := toolResult.Content[0].(mcp.TextContent).Text
+			if !strings.Contains(diff, "# 1 added, 0 removed, 0 changed\n## v1/Secret/release-to-diff-secret (added)\n") {
+				t.Fatalf("unexpected diff %v", diff)
+			}
+			if strings.Contains(diff, "YWl0YW5h") || !strings.Contains(diff, "+  username: REDACTED\n") {
+				t.Fatalf("expected redacted secret, got %v", diff)
+			}
+		})
+		t.Run("helm_diff doesn't install the release", func(t *testing.T) {
+			_, err = kc.CoreV1().Secrets("default").Get(c.ctx, "release-to-diff-secret", metav1.GetOptions{})
+			if !errors.IsNotFound(err) {
+				t.Fatalf("expected secret not to be created, got %v", err)
+			}
+		})
+		_, _ = c.callTool("helm_upgrade", map[string]interface{}{"chart": chartPath, "name": "release-to-diff", "install": true})
+		toolResult, err = c.callTool("helm_diff", map[string]interface{}{"chart": chartPath, "name": "release-to-diff"})
+		t.Run("helm_diff with existing release and same chart, returns no changes", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("call tool failed %v", err)
+			}
+			if toolResult.IsError {
+				t.Fatalf("call tool failed %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+			if !strings.HasSuffix(toolResult.Content[0].(mcp.TextContent).Text, "# 0 added, 0 removed, 0 changed\n## v1/Secret/release-to-diff-secret (no changes)\n") {
+				t.Fatalf("unexpected diff %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		_, _ = kc.CoreV1().Secrets("default").Create(c.ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "sh.helm.release.v1.release-to-diff.v2",
+				Labels: map[string]string{"owner": "helm", "name": "release-to-diff", "status": "failed", "version": "2"},
+			},
+			Data: map[string][]byte{
+				"release": []byte(base64.StdEncoding.EncodeToString([]byte("{" +
+					"\"name\":\"release-to-diff\",\"version\":2," +
+					"\"info\":{\"status\":\"failed\"}," +
+					"\"manifest\":\"apiVersion: v1\\nkind: ConfigMap\\nmetadata:\\n  name: configmap-from-failed-revision\\n  namespace: default\\n\"" +
+					"}"))),
+			},
+		}, metav1.CreateOptions{})
+		toolResult, err = c.callTool("helm_diff", map[string]interface{}{"chart": chartPath, "name": "release-to-diff"})
+		t.Run("helm_diff with failed last revision, returns the differences with the deployed revision", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("call tool failed %v", err)
+			}
+			if toolResult.IsError {
+				t.Fatalf("call tool failed %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+			diff := toolResult.Content[0].(mcp.TextContent).Text
+			if !strings.Contains(diff, "release release-to-diff (revision 1)") ||
+				!strings.HasSuffix(diff, "# 0 added, 0 removed, 0 changed\n## v1/Secret/release-to-diff-secret (no changes)\n") {
+				t.Fatalf("unexpected diff %v", diff)
+			}
+		})
+	})
+}
+
+func TestHelmGet(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		c.withEnvTest()
+		kc := c.newKubernetesClient()
+		clearHelmReleases(c.ctx, kc)
+		_, _ = kc.CoreV1().Secrets("default").Create(c.ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "sh.helm.release.v1.release-to-get.v1",
+				Labels: map[string]string{"owner": "helm", "name": "release-to-get", "version": "1"},
+			},
+			Data: map[string][]byte{
+				"release": []byte(base64.StdEncoding.EncodeToString([]byte("{" +
+					"\"name\":\"release-to-get\"," +
+					"\"version\":1," +
+					"\"info\":{\"status\":\"deployed\",\"notes\":\"Thank you for installing\"}," +
+					"\"config\":{\"key\":\"value\"}," +
+					"\"manifest\":\"---\\n# Source: chart/templates/secret.yaml\\napiVersion: v1\\nkind: Secret\\nmetadata:\\n  name: secret-from-release\\nstringData:\\n  password: s3cr3t\\n" +
+					"---\\n# Source: chart/templates/configmap.yaml\\napiVersion: v1\\nkind: ConfigMap\\nmetadata:\\n  name: configmap-from-release\\ndata:\\n  key: value\\n\"," +
+					"\"hooks\":[{\"name\":\"hook-job\",\"kind\":\"Job\",\"path\":\"chart/templates/hook.yaml\",\"manifest\":\"apiVersion: batch/v1\\nkind: Job\\nmetadata:\\n  name: hook-job\"}]" +
+					"}"))),
+			},
+		}, metav1.CreateOptions{})
+		toolResult, err := c.callTool("helm_get", map[string]interface{}{"name": "release-to-get", "mode": "values"})
+		t.Run("helm_get with values mode, returns user-supplied values", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("call tool failed %v", err)
+			}
+			if toolResult.IsError {
+				t.Fatalf("call tool failed %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "key: value\n" {
+				t.Fatalf("unexpected result %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		toolResult, err = c.callTool("helm_get", map[string]interface{}{"name": "release-to-get", "mode": "manifest"})
+		t.Run("helm_get with manifest mode, returns manifest with redacted secrets", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("call tool failed %v", err)
+			}
+			if toolResult.IsError {
+				t.Fatalf("call tool failed %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+			manifest := toolResult.Content[0].(mcp.TextContent).Text
+			if strings.Contains(manifest, "s3cr3t") || !strings.Contains(manifest, "password: REDACTED") {
+				t.Fatalf("expected redacted secret, got %v", manifest)
+			}
+			if !strings.Contains(manifest, "# Source: chart/templates/secret.yaml") || !strings.Contains(manifest, "name: configmap-from-release") {
+				t.Fatalf("unexpected manifest %v", manifest)
+			}
+		})
+		toolResult, err = c.callTool("helm_get", map[string]interface{}{"name": "release-to-get", "mode": "manifest", "show_secrets": true})
+		t.Run("helm_get with manifest mode and show_secrets, returns manifest with secrets", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("call tool failed %v", err)
+			}
+			if !strings.Contains(toolResult.Content[0].(mcp.TextContent).Text, "password: s3cr3t") {
+				t.Fatalf("expected secret, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		toolResult, err = c.callTool("helm_get", map[string]interface{}{"name": "release-to-get", "mode": "notes"})
+		t.Run("helm_get with notes mode, returns notes", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("call tool failed %v", err)
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "Thank you for installing" {
+				t.Fatalf("unexpected result %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		toolResult, err = c.callTool("helm_get", map[string]interface{}{"name": "release-to-get", "mode": "hooks"})
+		t.Run("helm_get with hooks mode, returns hooks", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("call tool failed %v", err)
+			}
+			if !strings.Contains(toolResult.Content[0].(mcp.TextContent).Text, "# Source: chart/templates/hook.yaml\napiVersion: batch/v1\nkind: Job") {
+				t.Fatalf("unexpected result %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		toolResult, _ = c.callTool("helm_get", map[string]interface{}{"name": "release-to-get", "mode": "chart"})
+		t.Run("helm_get with unsupported mode, returns error", func(t *testing.T) {
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			expectedMessage := "failed to get helm release 'release-to-get' chart: unsupported mode chart, supported modes are values, manifest, notes, hooks"
+			if toolResult.Content[0].(mcp.TextContent).Text != expectedMessage {
+				t.Fatalf("invalid error message, expected %s, got %v", expectedMessage, toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+	})
+}
+
+func TestHelmShowSecretsDenied(t *testing.T) {
+	for name, staticConfig := range map[string]*config.StaticConfig{
+		"denied Secrets": {DeniedResources: []config.GroupVersionKind{{Version: "v1", Kind: "Secret"}}},
+		"denied v1":      {DeniedResources: []config.GroupVersionKind{{Version: "v1"}}},
+		"read-only":      {ReadOnly: true},
+	} {
+		testCaseWithContext(t, &mcpContext{staticConfig: staticConfig}, func(c *mcpContext) {
+			mockServer := NewMockServer()
+			defer mockServer.Close()
+			c.withKubeConfig(mockServer.config)
+			helmGet, _ := c.callTool("helm_get", map[string]interface{}{"name": "release-to-get", "mode": "manifest", "show_secrets": true})
+			t.Run("helm_get with show_secrets is refused with "+name, func(t *testing.T) {
+				text := helmGet.Content[0].(mcp.TextContent).Text
+				if !helmGet.IsError || !strings.HasPrefix(text, "failed to get helm release 'release-to-get' manifest: show secrets is not allowed: ") {
+					t.Fatalf("expected descriptive error, got %v", text)
+				}
+			})
+			helmDiff, _ := c.callTool("helm_diff", map[string]interface{}{"chart": "chart", "name": "release-to-diff", "show_secrets": true})
+			t.Run("helm_diff with show_secrets is refused with "+name, func(t *testing.T) {
+				text := helmDiff.Content[0].(mcp.TextContent).Text
+				if !helmDiff.IsError || !strings.HasPrefix(text, "failed to diff helm release 'release-to-diff': show secrets is not allowed: ") {
+					t.Fatalf("expected descriptive error, got %v", text)
+				}
+			})
+		})
+	}
+}
+
+func TestHelmHistory(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		c.withEnvTest()
+		kc := c.newKubernetesClient()
+		clearHelmReleases(c.ctx, kc)
+		toolResult, err := c.callTool("helm_history", map[string]interface{}{"name": "release-with-history"})
+		t.Run("helm_history with no releases, returns not found", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("call tool failed %v", err)
+			}
+			if toolResult.IsError {
+				t.Fatalf("call tool failed")
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "Release release-with-history not found" {
+				t.Fatalf("unexpected result %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		for _, revision := range []string{"2", "1"} {
+			status := "superseded"
+			if revision == "2" {
+				status = "deployed"
+			}
+			_, _ = kc.CoreV1().Secrets("default").Create(c.ctx, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "sh.helm.release.v1.release-with-history.v" + revision,
+					Labels: map[string]string{"owner": "helm", "name": "release-with-history", "version": revision},
+				},
+				Data: map[string][]byte{
+					"release": []byte(base64.StdEncoding.EncodeToString([]byte("{" +
+						"\"name\":\"release-with-history\"," +
+						"\"version\":" + revision + "," +
+						"\"info\":{\"status\":\"" + status + "\",\"description\":\"Revision " + revision + "\"}," +
+						"\"chart\":{\"metadata\":{\"name\":\"no-op\",\"version\":\"1.33." + revision + "\"}}" +
+						"}"))),
+				},
+			}, metav1.CreateOptions{})
+		}
+		toolResult, err = c.callTool("helm_history", map[string]interface{}{"name": "release-with-history"})
+		t.Run("helm_history with release, returns revisions sorted", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("call tool failed %v", err)
+			}
+			if toolResult.IsError {
+				t.Fatalf("call tool failed %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+			var decoded []map[string]interface{}
+			err = yaml.Unmarshal([]byte(toolResult.Content[0].(mcp.TextContent).Text), &decoded)
+			if err != nil {
+				t.Fatalf("invalid tool result content %v", err)
+			}
+			if len(decoded) != 2 {
+				t.Fatalf("invalid helm history count, expected 2, got %v", len(decoded))
+			}
+			if decoded[0]["revision"] != float64(1) || decoded[0]["status"] != "superseded" || decoded[0]["chartVersion"] != "1.33.1" || decoded[0]["description"] != "Revision 1" {
+				t.Fatalf("invalid helm history first revision, got %v", decoded[0])
+			}
+			if decoded[1]["revision"] != float64(2) || decoded[1]["status"] != "deployed" || decoded[1]["chartVersion"] != "1.33.2" || decoded[1]["description"] != "Revision 2" {
+				t.Fatalf("invalid helm history second revision, got %v", decoded[1])
+			}
+		})
+		toolResult, err = c.callTool("helm_history", map[string]interface{}{"name": "release-with-history", "max": 1})
+		t.Run("helm_history with max, returns the most recent revisions", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("call tool failed %v", err)
+			}
+			var decoded []map[string]interface{}
+			err = yaml.Unmarshal([]byte(toolResult.Content[0].(mcp.TextContent).Text), &decoded)
+			if err != nil {
+				t.Fatalf("invalid tool result content %v", err)
+			}
+			if len(decoded) != 1 || decoded[0]["revision"] != float64(2) {
+				t.Fatalf("invalid helm history, expected revision 2 only, got %v", decoded)
+			}
+		})
+	})
+}
+
+func TestHelmRollback(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		c.withEnvTest()
+		kc := c.newKubernetesClient()
+		clearHelmReleases(c.ctx, kc)
+		_, file, _, _ := runtime.Caller(0)
+		chartPath := filepath.Join(filepath.Dir(file), "testdata", "helm-chart-no-op")
+		for range 2 {
+			_, _ = c.callTool("helm_upgrade", map[string]interface{}{"chart": chartPath, "name": "release-to-roll-back", "install": true})
+		}
+		toolResult, err := c.callTool("helm_rollback", map[string]interface{}{"name": "release-to-roll-back", "revision": 1})
+		t.Run("helm_rollback with revision, returns rolled back release", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("call tool failed %v", err)
+			}
+			if toolResult.IsError {
+				t.Fatalf("call tool failed %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+			var decoded []map[string]interface{}
+			err = yaml.Unmarshal([]byte(toolResult.Content[0].(mcp.TextContent).Text), &decoded)
+			if err != nil {
+				t.Fatalf("invalid tool result content %v", err)
+			}
+			if decoded[0]["revision"] != float64(3) || decoded[0]["previousRevision"] != float64(2) {
+				t.Fatalf("invalid helm rollback revisions, expected 2 -> 3, got %v -> %v", decoded[0]["previousRevision"], decoded[0]["revision"])
+			}
+			if decoded[0]["description"] != "Rollback to 1" {
+				t.Fatalf("invalid helm rollback description, expected Rollback to 1, got %v", decoded[0]["description"])
+			}
+			if decoded[0]["status"] != "deployed" {
+				t.Fatalf("invalid helm rollback status, expected deployed, got %v", decoded[0]["status"])
+			}
+		})
+		toolResult, _ = c.callTool("helm_rollback", map[string]interface{}{"name": "non-existent-release"})
+		t.Run("helm_rollback with non-existent release, returns error", func(t *testing.T) {
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+		})
+	})
+}
+
+func TestHelmStatus(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		c.withEnvTest()
+		kc := c.newKubernetesClient()
+		clearHelmReleases(c.ctx, kc)
+		_, _ = kc.CoreV1().ConfigMaps("default").Create(c.ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "configmap-from-release"},
+		}, metav1.CreateOptions{})
+		_, _ = kc.CoreV1().Secrets("default").Create(c.ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "sh.helm.release.v1.release-with-status.v1",
+				Labels: map[string]string{"owner": "helm", "name": "release-with-status", "version": "1"},
+			},
+			Data: map[string][]byte{
+				"release": []byte(base64.StdEncoding.EncodeToString([]byte("{" +
+					"\"name\":\"release-with-status\"," +
+					"\"namespace\":\"default\"," +
+					"\"version\":1," +
+					"\"info\":{\"status\":\"deployed\",\"description\":\"Install complete\",\"notes\":\"Thank you for installing\"}," +
+					"\"manifest\":\"apiVersion: v1\\nkind: ConfigMap\\nmetadata:\\n  name: configmap-from-release\\n  namespace: default\\n\"" +
+					"}"))),
+			},
+		}, metav1.CreateOptions{})
+		toolResult, err := c.callTool("helm_status", map[string]interface{}{"name": "release-with-status"})
+		t.Run("helm_status with release, returns release status", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("call tool failed %v", err)
+			}
+			if toolResult.IsError {
+				t.Fatalf("call tool failed %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+			var decoded map[string]interface{}
+			err = yaml.Unmarshal([]byte(toolResult.Content[0].(mcp.TextContent).Text), &decoded)
+			if err != nil {
+				t.Fatalf("invalid tool result content %v", err)
+			}
+			if decoded["status"] != "deployed" || decoded["description"] != "Install complete" {
+				t.Fatalf("invalid helm status, got %v", decoded)
+			}
+			if decoded["notes"] != "Thank you for installing" {
+				t.Fatalf("invalid helm status notes, got %v", decoded["notes"])
+			}
+			resources, ok := decoded["resources"].([]interface{})
+			if !ok || len(resources) != 1 {
+				t.Fatalf("invalid helm status resources, expected 1, got %v", decoded["resources"])
+			}
+			if resources[0].(map[string]interface{})["kind"] != "ConfigMap" || resources[0].(map[string]interface{})["name"] != "configmap-from-release" {
+				t.Fatalf("invalid helm status resource, got %v", resources[0])
+			}
+		})
+		toolResult, _ = c.callTool("helm_status", map[string]interface{}{"name": "non-existent-release"})
+		t.Run("helm_status with non-existent release, returns error", func(t *testing.T) {
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+		})
+	})
+}
+
 func clearHelmReleases(ctx context.Context, kc *kubernetes.Clientset) {
 	secrets, _ := kc.CoreV1().Secrets("default").List(ctx, metav1.ListOptions{})
 	for _, secret := range secrets.Items {
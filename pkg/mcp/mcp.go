@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"slices"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -38,27 +39,48 @@ func (c *Configuration) isToolApplicable(tool server.ServerTool) bool {
 	return true
 }
 
+// isRunSandboxJanitorEnabled returns true if the tools that create run sandbox resources with a TTL (pods_run, pods_debug)
+// are applicable and destructive operations (the janitor deletes the expired resources) are allowed
+func (c *Configuration) isRunSandboxJanitorEnabled(applicableTools []server.ServerTool) bool {
+	if c.StaticConfig.ReadOnly || c.StaticConfig.DisableDestructive {
+		return false
+	}
+	return slices.ContainsFunc(applicableTools, func(tool server.ServerTool) bool {
+		return tool.Tool.Name == "pods_run" || tool.Tool.Name == "pods_debug"
+	})
+}
+
+// runSandboxJanitorInterval is the period of the deletion of the expired pods_run sandbox resources
+var runSandboxJanitorInterval = time.Minute
+
 type Server struct {
 	configuration *Configuration
 	server        *server.MCPServer
 	k             *kubernetes.Manager
+	subscriptions *resourceSubscriptions
 }
 
 func NewServer(configuration Configuration) (*Server, error) {
 	s := &Server{
 		configuration: &configuration,
-		server: server.NewMCPServer(
-			version.BinaryName,
-			version.Version,
+		subscriptions: newResourceSubscriptions(),
+	}
+	s.server = server.NewMCPServer(
+		version.BinaryName,
+		version.Version,
+		slices.Concat([]server.ServerOption{
 			server.WithResourceCapabilities(true, true),
 			server.WithPromptCapabilities(true),
 			server.WithToolCapabilities(true),
 			server.WithLogging(),
-		),
-	}
+			server.WithHooks(s.initHooks()),
+		}, s.initCompletions())...,
+	)
 	if err := s.reloadKubernetesClient(); err != nil {
 		return nil, err
 	}
+	s.initResourceTemplates()
+	s.server.SetPrompts(s.configuration.Profile.GetPrompts(s)...)
 	s.k.WatchKubeConfig(s.reloadKubernetesClient)
 
 	return s, nil
@@ -69,6 +91,9 @@ func (s *Server) reloadKubernetesClient() error {
 	if err != nil {
 		return err
 	}
+	if s.k != nil {
+		s.k.StopRunSandboxJanitor()
+	}
 	s.k = k
 	applicableTools := make([]server.ServerTool, 0)
 	for _, tool := range s.configuration.Profile.GetTools(s) {
@@ -78,6 +103,9 @@ func (s *Server) reloadKubernetesClient() error {
 		applicableTools = append(applicableTools, tool)
 	}
 	s.server.SetTools(applicableTools...)
+	if s.configuration.isRunSandboxJanitorEnabled(applicableTools) {
+		s.k.StartRunSandboxJanitor(runSandboxJanitorInterval)
+	}
 	return nil
 }
 
@@ -102,6 +130,7 @@ func (s *Server) ServeHTTP() *server.StreamableHTTPServer {
 }
 
 func (s *Server) Close() {
+	s.subscriptions.close()
 	if s.k != nil {
 		s.k.Close()
 	}
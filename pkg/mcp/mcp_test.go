@@ -74,6 +74,13 @@ func TestReadOnly(t *testing.T) {
 				}
 			}
 		})
+		t.Run("ListTools doesn't return the tools executing commands in the containers", func(t *testing.T) {
+			for _, tool := range tools.Tools {
+				if tool.Name == "pods_exec" || tool.Name == "pods_cp_from" {
+					t.Errorf("Tool %s executes commands but is available in read-only mode", tool.Name)
+				}
+			}
+		})
 	})
 }
 
@@ -197,6 +204,32 @@ func TestIsToolApplicableReadOnly(t *testing.T) {
 
 }
 
+func TestIsRunSandboxJanitorEnabled(t *testing.T) {
+	podsRun := server.ServerTool{Tool: mcp.Tool{Name: "pods_run"}}
+	podsDebug := server.ServerTool{Tool: mcp.Tool{Name: "pods_debug"}}
+	podsList := server.ServerTool{Tool: mcp.Tool{Name: "pods_list"}}
+	tests := []struct {
+		name     string
+		config   *config.StaticConfig
+		tools    []server.ServerTool
+		expected bool
+	}{
+		{name: "pods_run applicable", config: &config.StaticConfig{}, tools: []server.ServerTool{podsList, podsRun}, expected: true},
+		{name: "pods_debug applicable", config: &config.StaticConfig{}, tools: []server.ServerTool{podsDebug}, expected: true},
+		{name: "pods_run and pods_debug not applicable", config: &config.StaticConfig{}, tools: []server.ServerTool{podsList}, expected: false},
+		{name: "read-only", config: &config.StaticConfig{ReadOnly: true}, tools: []server.ServerTool{podsRun}, expected: false},
+		{name: "destructive disabled", config: &config.StaticConfig{DisableDestructive: true}, tools: []server.ServerTool{podsRun}, expected: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			configuration := Configuration{StaticConfig: test.config}
+			if enabled := configuration.isRunSandboxJanitorEnabled(test.tools); enabled != test.expected {
+				t.Errorf("isRunSandboxJanitorEnabled should return %t, got %t", test.expected, enabled)
+			}
+		})
+	}
+}
+
 func TestIsToolApplicableEnabledTools(t *testing.T) {
 	testCaseWithContext(t, &mcpContext{
 		staticConfig: &config.StaticConfig{
@@ -260,6 +293,13 @@ func TestDisableDestructive(t *testing.T) {
 				}
 			}
 		})
+		t.Run("ListTools doesn't return the tools sending arbitrary HTTP requests", func(t *testing.T) {
+			for _, tool := range tools.Tools {
+				if tool.Name == "http_probe" {
+					t.Errorf("Tool %s sends DELETE requests but is available with destructive tools disabled", tool.Name)
+				}
+			}
+		})
 	})
 }
 
@@ -15,6 +15,8 @@ func (s *Server) initNamespaces() []server.ServerTool {
 	ret = append(ret, server.ServerTool{
 		Tool: mcp.NewTool("namespaces_list",
 			mcp.WithDescription("List all the Kubernetes namespaces in the current cluster"),
+			withListLimit(),
+			withListContinue(),
 			// Tool annotations
 			mcp.WithTitleAnnotation("Namespaces: List"),
 			mcp.WithReadOnlyHintAnnotation(true),
@@ -37,12 +39,16 @@ func (s *Server) initNamespaces() []server.ServerTool {
 	return ret
 }
 
-func (s *Server) namespacesList(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	ret, err := s.k.Derived(ctx).NamespacesList(ctx, kubernetes.ResourceListOptions{AsTable: s.configuration.ListOutput.AsTable()})
+func (s *Server) namespacesList(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	resourceListOptions := kubernetes.ResourceListOptions{AsTable: s.configuration.ListOutput.AsTable()}
+	if err := s.paginate(ctr.GetArguments(), &resourceListOptions); err != nil {
+		return NewTextResult("", fmt.Errorf("failed to list namespaces, %s", err)), nil
+	}
+	ret, err := s.k.Derived(ctx).NamespacesList(ctx, resourceListOptions)
 	if err != nil {
 		return NewTextResult("", fmt.Errorf("failed to list namespaces: %v", err)), nil
 	}
-	return NewTextResult(s.configuration.ListOutput.PrintObj(ret)), nil
+	return NewTextResult(s.printList(ret)), nil
 }
 
 func (s *Server) projectsList(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
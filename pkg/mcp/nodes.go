@@ -0,0 +1,303 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"k8s.io/kubectl/pkg/metricsutil"
+
+	"github.com/manusa/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/manusa/kubernetes-mcp-server/pkg/output"
+)
+
+const (
+	defaultNodesDrainTimeout = 60
+	defaultNodesLogTail      = 256
+)
+
+func (s *Server) initNodes() []server.ServerTool {
+	return []server.ServerTool{
+		{Tool: mcp.NewTool("nodes_list",
+			mcp.WithDescription("List all the Kubernetes Nodes in the current cluster"),
+			mcp.WithString("labelSelector", mcp.Description("Optional Kubernetes label selector (e.g. 'node-role.kubernetes.io/worker' or 'kubernetes.io/os=linux'), use this option when you want to filter the nodes by label"), mcp.Pattern("([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]")),
+			withListLimit(),
+			withListContinue(),
+			// Tool annotations
+			mcp.WithTitleAnnotation("Nodes: List"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.nodesList},
+		{Tool: mcp.NewTool("nodes_describe",
+			mcp.WithDescription("Describe a Kubernetes Node (same as kubectl describe node), including its conditions, taints, "+
+				"allocatable resources and the resources requested by its Pods, the non-terminated Pods scheduled on it, and its events"),
+			mcp.WithString("name", mcp.Description("Name of the Node"), mcp.Required()),
+			// Tool annotations
+			mcp.WithTitleAnnotation("Nodes: Describe"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.nodesDescribe},
+		{Tool: mcp.NewTool("nodes_top",
+			mcp.WithDescription("List the resource consumption (CPU and memory) as recorded by the Kubernetes Metrics Server for the specified Kubernetes Nodes or all the Nodes in the cluster, "+
+				"including the percentage of the allocatable resources of each Node"),
+			mcp.WithString("name", mcp.Description("Name of the Node to get the resource consumption from (Optional, all Nodes if not provided)")),
+			mcp.WithString("label_selector", mcp.Description("Kubernetes label selector (e.g. 'node-role.kubernetes.io/worker'), use this option when you want to filter the nodes by label (Optional, only applicable when name is not provided)"), mcp.Pattern("([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]")),
+			// Tool annotations
+			mcp.WithTitleAnnotation("Nodes: Top"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithIdempotentHintAnnotation(true),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.nodesTop},
+		{Tool: mcp.NewTool("nodes_log",
+			mcp.WithDescription("Get the logs of a Kubernetes Node, either of a service such as the kubelet or the container runtime read from the system journal (query), "+
+				"or of a log file in the /var/log directory of the Node (path), through the kubelet logs endpoint of the Node proxy"),
+			mcp.WithString("name", mcp.Description("Name of the Node"), mcp.Required()),
+			mcp.WithString("query", mcp.Description("Name of the service to get the logs of from the system journal, e.g. kubelet, containerd, or crio "+
+				"(requires the NodeLogQuery feature gate and the enableSystemLogQuery kubelet configuration)")),
+			mcp.WithString("path", mcp.Description("Path of the log file relative to /var/log, e.g. kube-proxy.log or pods/ (a directory path ending with / lists its content). "+
+				"Ignored if query is provided (Optional, the content of /var/log is listed if neither query nor path are provided)")),
+			mcp.WithNumber("tail", mcp.Description(fmt.Sprintf("Number of lines to return from the end of the logs (Optional, %d if not provided, -1 to return all the lines)", defaultNodesLogTail))),
+			mcp.WithString("sinceTime", mcp.Description("Only return logs after the provided RFC3339 timestamp, e.g. 2025-01-01T10:00:00Z (Optional, query only)")),
+			mcp.WithString("pattern", mcp.Description("Regular expression to filter the log lines, only the matching lines are returned, e.g. '(?i)error|failed' (Optional)")),
+			// Tool annotations
+			mcp.WithTitleAnnotation("Nodes: Log"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.nodesLog},
+		{Tool: mcp.NewTool("nodes_stats_summary",
+			mcp.WithDescription("Get the resource usage statistics of a Kubernetes Node and its Pods from the kubelet stats summary endpoint of the Node proxy, "+
+				"including the CPU, memory, network, and filesystem usage of the Node, and the CPU, memory, network, and ephemeral storage usage of each Pod "+
+				"(sorted by ephemeral storage usage)"),
+			mcp.WithString("name", mcp.Description("Name of the Node"), mcp.Required()),
+			mcp.WithString("namespace", mcp.Description("Namespace of the Pods to report (Optional, all the Pods in the Node if not provided)")),
+			// Tool annotations
+			mcp.WithTitleAnnotation("Nodes: Stats Summary"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.nodesStatsSummary},
+		{Tool: mcp.NewTool("nodes_cordon",
+			mcp.WithDescription("Cordon a Kubernetes Node, marking it as unschedulable so that no new Pods are scheduled on it (the running Pods are not affected)"),
+			mcp.WithString("name", mcp.Description("Name of the Node to cordon"), mcp.Required()),
+			// Tool annotations
+			mcp.WithTitleAnnotation("Nodes: Cordon"),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithIdempotentHintAnnotation(true),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.nodesCordon},
+		{Tool: mcp.NewTool("nodes_uncordon",
+			mcp.WithDescription("Uncordon a Kubernetes Node, marking it as schedulable again"),
+			mcp.WithString("name", mcp.Description("Name of the Node to uncordon"), mcp.Required()),
+			// Tool annotations
+			mcp.WithTitleAnnotation("Nodes: Uncordon"),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithIdempotentHintAnnotation(true),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.nodesUncordon},
+		{Tool: mcp.NewTool("nodes_drain",
+			mcp.WithDescription("Drain a Kubernetes Node in preparation for maintenance (same as kubectl drain): the Node is cordoned and its Pods are evicted through the eviction API, honoring their PodDisruptionBudgets. "+
+				"Evictions rejected by a PodDisruptionBudget are retried, and the evicted Pods are waited for until they're deleted, until the timeout expires. "+
+				"The Pods that could not be evicted are reported with the reason, the evicted Pods that are still terminating are reported too. "+
+				"No Pod is evicted if any of them can't be deleted with the provided options (e.g. DaemonSet-managed Pods without ignoreDaemonSets, or unmanaged Pods without force). "+
+				"Use nodes_uncordon once the maintenance is over"),
+			mcp.WithString("name", mcp.Description("Name of the Node to drain"), mcp.Required()),
+			mcp.WithBoolean("ignoreDaemonSets", mcp.Description("Ignore the DaemonSet-managed Pods, they are recreated on the Node by their controller anyway (Optional, true if not provided)"), mcp.DefaultBool(true)),
+			mcp.WithBoolean("deleteEmptyDirData", mcp.Description("Evict the Pods using emptyDir volumes, their local data is lost (Optional)")),
+			mcp.WithBoolean("force", mcp.Description("Evict the Pods not managed by a controller (ReplicaSet, Job, StatefulSet...), they are not recreated (Optional)")),
+			mcp.WithNumber("gracePeriodSeconds", mcp.Description("Termination grace period of the evicted Pods in seconds (Optional, the Pod's own terminationGracePeriodSeconds if not provided)"), mcp.Min(0)),
+			mcp.WithNumber("timeout", mcp.Description(fmt.Sprintf("Maximum number of seconds to retry the evictions rejected by a PodDisruptionBudget and to wait for the evicted Pods to be deleted (Optional, %d if not provided)", defaultNodesDrainTimeout)), mcp.Min(1)),
+			// Tool annotations
+			mcp.WithTitleAnnotation("Nodes: Drain"),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(true),
+			mcp.WithIdempotentHintAnnotation(true),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.nodesDrain},
+	}
+}
+
+func (s *Server) nodesList(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	resourceListOptions := kubernetes.ResourceListOptions{AsTable: s.configuration.ListOutput.AsTable()}
+	if labelSelector, ok := ctr.GetArguments()["labelSelector"].(string); ok {
+		resourceListOptions.ListOptions.LabelSelector = labelSelector
+	}
+	if err := s.paginate(ctr.GetArguments(), &resourceListOptions); err != nil {
+		return NewTextResult("", fmt.Errorf("failed to list nodes, %s", err)), nil
+	}
+	ret, err := s.k.Derived(ctx).NodesList(ctx, resourceListOptions)
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to list nodes: %v", err)), nil
+	}
+	return NewTextResult(s.printList(ret)), nil
+}
+
+func (s *Server) nodesDescribe(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	name, ok := ctr.GetArguments()["name"].(string)
+	if !ok || name == "" {
+		return NewTextResult("", fmt.Errorf("failed to describe node, missing argument name")), nil
+	}
+	ret, err := s.k.Derived(ctx).NodesDescribe(ctx, name)
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to describe node %s: %v", name, err)), nil
+	}
+	return NewTextResult(ret, nil), nil
+}
+
+func (s *Server) nodesTop(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	nodesTopOptions := kubernetes.NodesTopOptions{}
+	if v, ok := ctr.GetArguments()["name"].(string); ok {
+		nodesTopOptions.Name = v
+	}
+	if v, ok := ctr.GetArguments()["label_selector"].(string); ok {
+		nodesTopOptions.LabelSelector = v
+	}
+	ret, allocatable, err := s.k.Derived(ctx).NodesTop(ctx, nodesTopOptions)
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to get nodes top: %v", err)), nil
+	}
+	buf := new(bytes.Buffer)
+	printer := metricsutil.NewTopCmdPrinter(buf)
+	err = printer.PrintNodeMetrics(ret.Items, allocatable, false, "")
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to get nodes top: %v", err)), nil
+	}
+	return NewTextResult(buf.String(), nil), nil
+}
+
+func (s *Server) nodesLog(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	name, ok := ctr.GetArguments()["name"].(string)
+	if !ok || name == "" {
+		return NewTextResult("", fmt.Errorf("failed to get node log, missing argument name")), nil
+	}
+	options := kubernetes.NodesLogOptions{TailLines: defaultNodesLogTail}
+	options.Query, _ = ctr.GetArguments()["query"].(string)
+	options.Path, _ = ctr.GetArguments()["path"].(string)
+	options.SinceTime, _ = ctr.GetArguments()["sinceTime"].(string)
+	options.Pattern, _ = ctr.GetArguments()["pattern"].(string)
+	if v, ok := ctr.GetArguments()["tail"].(float64); ok {
+		options.TailLines = int64(v)
+		if options.TailLines < 0 {
+			options.TailLines = 0
+		}
+	}
+	if options.SinceTime != "" {
+		if options.Query == "" {
+			return NewTextResult("", fmt.Errorf("failed to get node log, sinceTime can only be used with query")), nil
+		}
+		if _, err := time.Parse(time.RFC3339, options.SinceTime); err != nil {
+			return NewTextResult("", fmt.Errorf("failed to get node log, sinceTime %s is not a valid RFC3339 timestamp", options.SinceTime)), nil
+		}
+	}
+	ret, err := s.k.Derived(ctx).NodesLog(ctx, name, options)
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to get node %s log: %v", name, err)), nil
+	}
+	if ret == "" {
+		ret = fmt.Sprintf("The node %s has not logged any message matching the provided options", name)
+	}
+	return NewTextResult(ret, nil), nil
+}
+
+func (s *Server) nodesStatsSummary(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	name, ok := ctr.GetArguments()["name"].(string)
+	if !ok || name == "" {
+		return NewTextResult("", fmt.Errorf("failed to get node stats summary, missing argument name")), nil
+	}
+	namespace, _ := ctr.GetArguments()["namespace"].(string)
+	ret, err := s.k.Derived(ctx).NodesStatsSummary(ctx, name, namespace)
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to get node %s stats summary: %v", name, err)), nil
+	}
+	marshalledYaml, err := output.MarshalYaml(ret)
+	if err != nil {
+		err = fmt.Errorf("failed to get node %s stats summary: %v", name, err)
+	}
+	return NewTextResult("# The resource usage statistics of the node and its pods (YAML) are:\n"+marshalledYaml, err), nil
+}
+
+func (s *Server) nodesCordon(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	name, ok := ctr.GetArguments()["name"].(string)
+	if !ok || name == "" {
+		return NewTextResult("", fmt.Errorf("failed to cordon node, missing argument name")), nil
+	}
+	changed, err := s.k.Derived(ctx).NodesCordon(ctx, name)
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to cordon node %s: %v", name, err)), nil
+	}
+	if !changed {
+		return NewTextResult(fmt.Sprintf("Node %s already cordoned", name), nil), nil
+	}
+	return NewTextResult(fmt.Sprintf("Node %s cordoned", name), nil), nil
+}
+
+func (s *Server) nodesUncordon(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	name, ok := ctr.GetArguments()["name"].(string)
+	if !ok || name == "" {
+		return NewTextResult("", fmt.Errorf("failed to uncordon node, missing argument name")), nil
+	}
+	changed, err := s.k.Derived(ctx).NodesUncordon(ctx, name)
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to uncordon node %s: %v", name, err)), nil
+	}
+	if !changed {
+		return NewTextResult(fmt.Sprintf("Node %s already uncordoned", name), nil), nil
+	}
+	return NewTextResult(fmt.Sprintf("Node %s uncordoned", name), nil), nil
+}
+
+func (s *Server) nodesDrain(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	name, ok := ctr.GetArguments()["name"].(string)
+	if !ok || name == "" {
+		return NewTextResult("", fmt.Errorf("failed to drain node, missing argument name")), nil
+	}
+	options := kubernetes.NodesDrainOptions{
+		IgnoreDaemonSets:   true,
+		GracePeriodSeconds: -1,
+		Timeout:            defaultNodesDrainTimeout * time.Second,
+	}
+	if v, ok := ctr.GetArguments()["ignoreDaemonSets"].(bool); ok {
+		options.IgnoreDaemonSets = v
+	}
+	if v, ok := ctr.GetArguments()["deleteEmptyDirData"].(bool); ok {
+		options.DeleteEmptyDirData = v
+	}
+	if v, ok := ctr.GetArguments()["force"].(bool); ok {
+		options.Force = v
+	}
+	if v, ok := ctr.GetArguments()["gracePeriodSeconds"].(float64); ok {
+		if v < 0 {
+			return NewTextResult("", fmt.Errorf("failed to drain node, gracePeriodSeconds must not be negative")), nil
+		}
+		options.GracePeriodSeconds = int(v)
+	}
+	if v, ok := ctr.GetArguments()["timeout"].(float64); ok {
+		if v < 1 {
+			return NewTextResult("", fmt.Errorf("failed to drain node, timeout must be a positive number")), nil
+		}
+		options.Timeout = time.Duration(v * float64(time.Second))
+	}
+	ret, err := s.k.Derived(ctx).NodesDrain(ctx, name, options)
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to drain node %s: %v", name, err)), nil
+	}
+	marshalledYaml, err := output.MarshalYaml(ret)
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to drain node %s: %v", name, err)), nil
+	}
+	if len(ret.Failed) > 0 {
+		return NewTextResult("# The node was cordoned but some pods could not be evicted (YAML)\n"+marshalledYaml, nil), nil
+	}
+	if len(ret.Terminating) > 0 {
+		return NewTextResult("# The node was cordoned and its pods were evicted but some pods are still terminating (YAML)\n"+marshalledYaml, nil), nil
+	}
+	return NewTextResult("# The node was drained successfully (YAML)\n"+marshalledYaml, nil), nil
+}
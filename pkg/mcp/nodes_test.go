@@ -0,0 +1,546 @@
+package mcp
+
+import (
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/yaml"
+
+	"github.com/manusa/kubernetes-mcp-server/pkg/config"
+	"github.com/manusa/kubernetes-mcp-server/pkg/kubernetes"
+)
+
+// nodesMockServer returns a mock server with the node-1 Node and its Pods, the patches and evictions it receives are recorded.
+// The evicted pod-evictable Pod is deleted, the evicted pod-terminating Pod never completes its termination.
+func nodesMockServer(lock *sync.Mutex, unschedulable *bool, evictions *[]string) *MockServer {
+	mockServer := NewMockServer()
+	owner := func(kind, name string) []metav1.OwnerReference {
+		return []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: kind, Name: name, Controller: ptr.To(true)}}
+	}
+	mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		switch req.URL.Path {
+		case "/api/v1/nodes/node-1":
+			if req.Method == http.MethodPatch {
+				body, _ := io.ReadAll(req.Body)
+				*unschedulable = strings.Contains(string(body), `"unschedulable":true`)
+			}
+			writeObject(w, &v1.Node{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Node"},
+				ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+				Spec:       v1.NodeSpec{Unschedulable: *unschedulable},
+				Status: v1.NodeStatus{
+					Conditions:  []v1.NodeCondition{{Type: v1.NodeReady, Status: v1.ConditionTrue, Reason: "KubeletReady"}},
+					Allocatable: v1.ResourceList{v1.ResourceCPU: resource.MustParse("2"), v1.ResourceMemory: resource.MustParse("4Gi")},
+				},
+			})
+		case "/api/v1/pods":
+			writeObject(w, &v1.PodList{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "PodList"}, Items: []v1.Pod{
+				{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pod-evictable", UID: "uid-evictable", OwnerReferences: owner("ReplicaSet", "rs")},
+					Spec: v1.PodSpec{NodeName: "node-1", Containers: []v1.Container{{Name: "c", Resources: v1.ResourceRequirements{
+						Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("500m")},
+					}}}}},
+				{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pod-protected", UID: "uid-protected", OwnerReferences: owner("ReplicaSet", "rs")},
+					Spec: v1.PodSpec{NodeName: "node-1", Containers: []v1.Container{{Name: "c"}}}},
+				{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pod-terminating", UID: "uid-terminating", OwnerReferences: owner("ReplicaSet", "rs")},
+					Spec: v1.PodSpec{NodeName: "node-1", Containers: []v1.Container{{Name: "c"}}}},
+				{ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "pod-daemon", UID: "uid-daemon", OwnerReferences: owner("DaemonSet", "ds")},
+					Spec: v1.PodSpec{NodeName: "node-1", Containers: []v1.Container{{Name: "c"}}}},
+			}})
+		case "/api/v1/namespaces/default/pods/pod-evictable":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"NotFound","code":404,` +
+				`"message":"pods \"pod-evictable\" not found"}`))
+		case "/api/v1/namespaces/default/pods/pod-terminating":
+			writeObject(w, &v1.Pod{
+				TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pod-terminating", UID: "uid-terminating",
+					DeletionTimestamp: &metav1.Time{Time: time.Now()}},
+			})
+		case "/apis/apps/v1/namespaces/kube-system/daemonsets/ds":
+			writeObject(w, &appsv1.DaemonSet{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "DaemonSet"},
+				ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "ds"},
+			})
+		case "/api/v1/events":
+			writeObject(w, &v1.EventList{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "EventList"}})
+		case "/api/v1/namespaces/default/pods/pod-evictable/eviction":
+			*evictions = append(*evictions, "default/pod-evictable")
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Success"}`))
+		case "/api/v1/namespaces/default/pods/pod-terminating/eviction":
+			*evictions = append(*evictions, "default/pod-terminating")
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Success"}`))
+		case "/api/v1/namespaces/default/pods/pod-protected/eviction":
+			*evictions = append(*evictions, "default/pod-protected")
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"TooManyRequests","code":429,` +
+				`"message":"Cannot evict pod as it would violate the pod's disruption budget."}`))
+		}
+	}))
+	return mockServer
+}
+
+func TestNodesDescribe(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		lock, unschedulable, evictions := &sync.Mutex{}, false, make([]string, 0)
+		mockServer := nodesMockServer(lock, &unschedulable, &evictions)
+		defer mockServer.Close()
+		c.withKubeConfig(mockServer.config)
+		t.Run("nodes_describe with missing name returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("nodes_describe", map[string]interface{}{})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "failed to describe node, missing argument name" {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		toolResult, err := c.callTool("nodes_describe", map[string]interface{}{"name": "node-1"})
+		t.Run("nodes_describe returns the node description", func(t *testing.T) {
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			text := toolResult.Content[0].(mcp.TextContent).Text
+			for _, expected := range []string{
+				`(?m)^Name:\s+node-1$`,
+				`(?m)^Unschedulable:\s+false$`,
+				`(?m)^\s+Ready\s+True\s+.+KubeletReady`,
+				`(?m)^Allocatable:$`,
+				`(?m)^Non-terminated Pods:\s+\(4 in total\)$`,
+				`(?m)^\s+default\s+pod-evictable\s+500m \(25%\)`,
+				`(?m)^Allocated resources:$`,
+			} {
+				if !regexp.MustCompile(expected).MatchString(text) {
+					t.Errorf("expected %s in description, got %v", expected, text)
+				}
+			}
+		})
+	})
+}
+
+func TestNodesCordon(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		lock, unschedulable, evictions := &sync.Mutex{}, false, make([]string, 0)
+		mockServer := nodesMockServer(lock, &unschedulable, &evictions)
+		defer mockServer.Close()
+		c.withKubeConfig(mockServer.config)
+		t.Run("nodes_cordon marks the node as unschedulable", func(t *testing.T) {
+			toolResult, err := c.callTool("nodes_cordon", map[string]interface{}{"name": "node-1"})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "Node node-1 cordoned" {
+				t.Errorf("unexpected result, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+			if !unschedulable {
+				t.Errorf("node not patched as unschedulable")
+			}
+		})
+		t.Run("nodes_cordon with cordoned node reports it", func(t *testing.T) {
+			toolResult, err := c.callTool("nodes_cordon", map[string]interface{}{"name": "node-1"})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "Node node-1 already cordoned" {
+				t.Errorf("unexpected result, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("nodes_uncordon marks the node as schedulable", func(t *testing.T) {
+			toolResult, err := c.callTool("nodes_uncordon", map[string]interface{}{"name": "node-1"})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "Node node-1 uncordoned" {
+				t.Errorf("unexpected result, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+			if unschedulable {
+				t.Errorf("node not patched as schedulable")
+			}
+		})
+	})
+}
+
+func TestNodesDrain(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		lock, unschedulable, evictions := &sync.Mutex{}, false, make([]string, 0)
+		mockServer := nodesMockServer(lock, &unschedulable, &evictions)
+		defer mockServer.Close()
+		c.withKubeConfig(mockServer.config)
+		t.Run("nodes_drain without ignoreDaemonSets evicts no pod", func(t *testing.T) {
+			toolResult, _ := c.callTool("nodes_drain", map[string]interface{}{"name": "node-1", "ignoreDaemonSets": false})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			text := toolResult.Content[0].(mcp.TextContent).Text
+			if !strings.HasPrefix(text, "failed to drain node node-1: node node-1 was cordoned but no Pod was evicted: ") ||
+				!strings.Contains(text, "kube-system/pod-daemon") {
+				t.Errorf("invalid error message, got %v", text)
+			}
+			if !unschedulable {
+				t.Errorf("node not cordoned")
+			}
+			if len(evictions) != 0 {
+				t.Errorf("no pod should be evicted, got %v", evictions)
+			}
+		})
+		toolResult, err := c.callTool("nodes_drain", map[string]interface{}{"name": "node-1", "timeout": 1})
+		t.Run("nodes_drain evicts pods and reports the pods that could not be evicted or are still terminating", func(t *testing.T) {
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			text := toolResult.Content[0].(mcp.TextContent).Text
+			if !strings.HasPrefix(text, "# The node was cordoned but some pods could not be evicted (YAML)\n") {
+				t.Fatalf("unexpected result, got %v", text)
+			}
+			var result kubernetes.NodesDrainResult
+			if err = yaml.Unmarshal([]byte(text), &result); err != nil {
+				t.Fatalf("invalid tool result content %v", err)
+			}
+			if len(result.Evicted) != 1 || result.Evicted[0] != "default/pod-evictable" {
+				t.Errorf("unexpected evicted pods, got %v", result.Evicted)
+			}
+			if len(result.Terminating) != 1 || result.Terminating[0] != "default/pod-terminating" {
+				t.Errorf("unexpected terminating pods, got %v", result.Terminating)
+			}
+			if len(result.Failed) != 1 || result.Failed[0].Pod != "default/pod-protected" ||
+				!strings.Contains(result.Failed[0].Reason, "disruption budget") {
+				t.Errorf("unexpected failed pods, got %v", result.Failed)
+			}
+			if !strings.Contains(result.Warnings, "ignoring DaemonSet-managed Pods: kube-system/pod-daemon") {
+				t.Errorf("unexpected warnings, got %v", result.Warnings)
+			}
+		})
+	})
+}
+
+func TestNodesDenied(t *testing.T) {
+	deniedResourcesServer := &config.StaticConfig{DeniedResources: []config.GroupVersionKind{{Group: "policy", Version: "v1", Kind: "Eviction"}}}
+	testCaseWithContext(t, &mcpContext{staticConfig: deniedResourcesServer}, func(c *mcpContext) {
+		lock, unschedulable, evictions := &sync.Mutex{}, false, make([]string, 0)
+		mockServer := nodesMockServer(lock, &unschedulable, &evictions)
+		defer mockServer.Close()
+		c.withKubeConfig(mockServer.config)
+		toolResult, _ := c.callTool("nodes_drain", map[string]interface{}{"name": "node-1"})
+		t.Run("nodes_drain describes denial", func(t *testing.T) {
+			expectedMessage := "failed to drain node node-1: resource not allowed: policy/v1, Kind=Eviction"
+			if !toolResult.IsError || toolResult.Content[0].(mcp.TextContent).Text != expectedMessage {
+				t.Fatalf("expected descriptive error '%s', got %v", expectedMessage, toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("nodes_drain doesn't cordon the node", func(t *testing.T) {
+			if unschedulable {
+				t.Errorf("node should not be cordoned")
+			}
+		})
+		t.Run("nodes_cordon is allowed", func(t *testing.T) {
+			toolResult, err := c.callTool("nodes_cordon", map[string]interface{}{"name": "node-1"})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+		})
+	})
+}
+
+func TestNodesDeniedByHelpers(t *testing.T) {
+	deniedResourcesServer := &config.StaticConfig{DeniedResources: []config.GroupVersionKind{{Group: "apps", Version: "v1", Kind: "DaemonSet"}}}
+	testCaseWithContext(t, &mcpContext{staticConfig: deniedResourcesServer}, func(c *mcpContext) {
+		lock, unschedulable, evictions := &sync.Mutex{}, false, make([]string, 0)
+		mockServer := nodesMockServer(lock, &unschedulable, &evictions)
+		defer mockServer.Close()
+		c.withKubeConfig(mockServer.config)
+		toolResult, _ := c.callTool("nodes_drain", map[string]interface{}{"name": "node-1"})
+		t.Run("nodes_drain describes denial of the kinds requested by the drain helpers", func(t *testing.T) {
+			text := toolResult.Content[0].(mcp.TextContent).Text
+			if !toolResult.IsError || !strings.Contains(text, "resource not allowed: apps/v1, Kind=DaemonSet") {
+				t.Fatalf("expected descriptive error, got %v", text)
+			}
+		})
+		t.Run("nodes_drain evicts no pod", func(t *testing.T) {
+			if len(evictions) != 0 {
+				t.Errorf("no pod should be evicted, got %v", evictions)
+			}
+		})
+	})
+}
+
+func TestNodesTop(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		mockServer := NewMockServer()
+		defer mockServer.Close()
+		c.withKubeConfig(mockServer.config)
+		var metricsLabelSelector string
+		mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch req.URL.Path {
+			// Request Performed by DiscoveryClient to Kube API (Get API Groups legacy -core-)
+			case "/api":
+				_, _ = w.Write([]byte(`{"kind":"APIVersions","versions":["metrics.k8s.io/v1beta1"],"serverAddressByClientCIDRs":[{"clientCIDR":"0.0.0.0/0"}]}`))
+			// Request Performed by DiscoveryClient to Kube API (Get API Groups)
+			case "/apis":
+				_, _ = w.Write([]byte(`{"kind":"APIGroupList","apiVersion":"v1","groups":[]}`))
+			// Request Performed by DiscoveryClient to Kube API (Get API Resources)
+			case "/apis/metrics.k8s.io/v1beta1":
+				_, _ = w.Write([]byte(`{"kind":"APIResourceList","apiVersion":"v1","groupVersion":"metrics.k8s.io/v1beta1","resources":[{"name":"nodes","singularName":"","namespaced":false,"kind":"NodeMetrics","verbs":["get","list"]}]}`))
+			case "/apis/metrics.k8s.io/v1beta1/nodes":
+				metricsLabelSelector = req.URL.Query().Get("labelSelector")
+				_, _ = w.Write([]byte(`{"kind":"NodeMetricsList","apiVersion":"metrics.k8s.io/v1beta1","items":[` +
+					`{"metadata":{"name":"node-1"},"usage":{"cpu":"500m","memory":"1Gi"}},` +
+					`{"metadata":{"name":"node-2"},"usage":{"cpu":"1","memory":"3Gi"}}` +
+					`]}`))
+			case "/apis/metrics.k8s.io/v1beta1/nodes/node-2":
+				_, _ = w.Write([]byte(`{"kind":"NodeMetrics","apiVersion":"metrics.k8s.io/v1beta1","metadata":{"name":"node-2"},"usage":{"cpu":"1","memory":"3Gi"}}`))
+			case "/api/v1/nodes":
+				_, _ = w.Write([]byte(`{"kind":"NodeList","apiVersion":"v1","items":[` +
+					`{"metadata":{"name":"node-1"},"status":{"allocatable":{"cpu":"2","memory":"4Gi"}}},` +
+					`{"metadata":{"name":"node-2"},"status":{"allocatable":{"cpu":"4","memory":"4Gi"}}}` +
+					`]}`))
+			case "/api/v1/nodes/node-2":
+				_, _ = w.Write([]byte(`{"kind":"Node","apiVersion":"v1","metadata":{"name":"node-2"},"status":{"allocatable":{"cpu":"4","memory":"4Gi"}}}`))
+			}
+		}))
+		nodesTop, err := c.callTool("nodes_top", map[string]interface{}{})
+		t.Run("nodes_top returns metrics of all nodes with allocatable percentages", func(t *testing.T) {
+			if err != nil || nodesTop.IsError {
+				t.Fatalf("call tool failed %v %v", err, nodesTop.Content)
+			}
+			text := nodesTop.Content[0].(mcp.TextContent).Text
+			for _, expected := range []string{
+				`(?m)^NAME\s+CPU\(cores\)\s+CPU\(%\)\s+MEMORY\(bytes\)\s+MEMORY\(%\)\s*$`,
+				`(?m)^node-1\s+500m\s+25%\s+1024Mi\s+25%\s*$`,
+				`(?m)^node-2\s+1000m\s+25%\s+3072Mi\s+75%\s*$`,
+			} {
+				if !regexp.MustCompile(expected).MatchString(text) {
+					t.Errorf("expected %s in output, got %v", expected, text)
+				}
+			}
+		})
+		nodesTopName, err := c.callTool("nodes_top", map[string]interface{}{"name": "node-2"})
+		t.Run("nodes_top with name returns metrics of the node", func(t *testing.T) {
+			if err != nil || nodesTopName.IsError {
+				t.Fatalf("call tool failed %v %v", err, nodesTopName.Content)
+			}
+			text := nodesTopName.Content[0].(mcp.TextContent).Text
+			if !regexp.MustCompile(`(?m)^node-2\s+1000m\s+25%\s+3072Mi\s+75%\s*$`).MatchString(text) || strings.Contains(text, "node-1") {
+				t.Errorf("unexpected output, got %v", text)
+			}
+		})
+		nodesTopLabelSelector, err := c.callTool("nodes_top", map[string]interface{}{"label_selector": "node-role.kubernetes.io/worker"})
+		t.Run("nodes_top with label_selector filters the node metrics by label", func(t *testing.T) {
+			if err != nil || nodesTopLabelSelector.IsError {
+				t.Fatalf("call tool failed %v %v", err, nodesTopLabelSelector.Content)
+			}
+			if metricsLabelSelector != "node-role.kubernetes.io/worker" {
+				t.Errorf("invalid label selector, expected node-role.kubernetes.io/worker, got %v", metricsLabelSelector)
+			}
+		})
+	})
+}
+
+func TestNodesList(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		c.withEnvTest()
+		_, _ = c.newKubernetesClient().CoreV1().Nodes().Create(c.ctx, &v1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "a-node-to-list", Labels: map[string]string{"node-role.kubernetes.io/worker": ""}},
+		}, metav1.CreateOptions{})
+		toolResult, err := c.callTool("nodes_list", map[string]interface{}{"labelSelector": "node-role.kubernetes.io/worker"})
+		t.Run("nodes_list returns nodes list", func(t *testing.T) {
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			if !strings.Contains(toolResult.Content[0].(mcp.TextContent).Text, "name: a-node-to-list") {
+				t.Errorf("node not listed, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+	})
+}
+
+func TestNodesLog(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		mockServer := NewMockServer()
+		defer mockServer.Close()
+		c.withKubeConfig(mockServer.config)
+		mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			switch req.URL.Path {
+			case "/api/v1/nodes/node-1/proxy/logs/":
+				w.Header().Set("Content-Type", "text/plain")
+				if req.URL.Query().Get("query") == "" {
+					_, _ = w.Write([]byte("<pre>\n<a href=\"kube-proxy.log\">kube-proxy.log</a>\n</pre>\n"))
+					return
+				}
+				_, _ = w.Write([]byte("query=" + req.URL.Query().Get("query") +
+					" tailLines=" + req.URL.Query().Get("tailLines") +
+					" sinceTime=" + req.URL.Query().Get("sinceTime") +
+					" pattern=" + req.URL.Query().Get("pattern") + "\n"))
+			case "/api/v1/nodes/node-1/proxy/logs/pods/":
+				w.Header().Set("Content-Type", "text/plain")
+				_, _ = w.Write([]byte("<pre>\n<a href=\"default_a-pod/\">default_a-pod/</a>\n</pre>\n"))
+			case "/api/v1/nodes/node-1/proxy/logs/kube-proxy.log":
+				w.Header().Set("Content-Type", "text/plain")
+				_, _ = w.Write([]byte("I0101 line 1\nE0101 error 2\nI0101 line 3\nE0101 error 4\nI0101 line 5\n"))
+			default:
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte("unexpected request " + req.URL.Path))
+			}
+		}))
+		t.Run("nodes_log with sinceTime and no query returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("nodes_log", map[string]interface{}{"name": "node-1", "path": "kube-proxy.log", "sinceTime": "2025-01-01T10:00:00Z"})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "failed to get node log, sinceTime can only be used with query" {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("nodes_log with query sends the query to the kubelet", func(t *testing.T) {
+			toolResult, err := c.callTool("nodes_log", map[string]interface{}{
+				"name": "node-1", "query": "kubelet", "tail": 10, "sinceTime": "2025-01-01T10:00:00Z", "pattern": "error",
+			})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			expected := "query=kubelet tailLines=10 sinceTime=2025-01-01T10:00:00Z pattern=error\n"
+			if toolResult.Content[0].(mcp.TextContent).Text != expected {
+				t.Errorf("unexpected result, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("nodes_log without query nor path lists the log directory", func(t *testing.T) {
+			toolResult, err := c.callTool("nodes_log", map[string]interface{}{"name": "node-1"})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			if !strings.Contains(toolResult.Content[0].(mcp.TextContent).Text, "kube-proxy.log") {
+				t.Errorf("unexpected result, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("nodes_log with directory path lists the directory", func(t *testing.T) {
+			toolResult, err := c.callTool("nodes_log", map[string]interface{}{"name": "node-1", "path": "/pods/"})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			if !strings.Contains(toolResult.Content[0].(mcp.TextContent).Text, "default_a-pod/") {
+				t.Errorf("unexpected result, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		for _, logPath := range []string{"../../../../namespaces/kube-system/secrets", "pods/../../secrets", "/../../etc/shadow"} {
+			t.Run("nodes_log with path outside /var/log returns error "+logPath, func(t *testing.T) {
+				toolResult, _ := c.callTool("nodes_log", map[string]interface{}{"name": "node-1", "path": logPath})
+				if !toolResult.IsError {
+					t.Fatalf("call tool should fail")
+				}
+				expected := "failed to get node node-1 log: invalid path " + logPath + ": must be relative to /var/log"
+				if toolResult.Content[0].(mcp.TextContent).Text != expected {
+					t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+				}
+			})
+		}
+		t.Run("nodes_log with name containing / returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("nodes_log", map[string]interface{}{"name": "../namespaces/kube-system/secrets/", "path": "kube-proxy.log"})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			if !strings.HasSuffix(toolResult.Content[0].(mcp.TextContent).Text, `invalid node name "../namespaces/kube-system/secrets/"`) {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("nodes_log with path returns the tail of the matching lines of the file", func(t *testing.T) {
+			toolResult, err := c.callTool("nodes_log", map[string]interface{}{"name": "node-1", "path": "kube-proxy.log", "pattern": "^I", "tail": 2})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "I0101 line 3\nI0101 line 5\n" {
+				t.Errorf("unexpected result, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("nodes_log with path and no matching lines reports it", func(t *testing.T) {
+			toolResult, err := c.callTool("nodes_log", map[string]interface{}{"name": "node-1", "path": "kube-proxy.log", "pattern": "fatal"})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "The node node-1 has not logged any message matching the provided options" {
+				t.Errorf("unexpected result, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+	})
+}
+
+func TestNodesStatsSummary(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		mockServer := NewMockServer()
+		defer mockServer.Close()
+		c.withKubeConfig(mockServer.config)
+		mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if req.URL.Path != "/api/v1/nodes/node-1/proxy/stats/summary" {
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"node":{"nodeName":"node-1","cpu":{"usageNanoCores":500000000},"fs":{"availableBytes":1000,"capacityBytes":3000,"usedBytes":2000}},"pods":[` +
+				`{"podRef":{"name":"pod-small","namespace":"default"},"network":{"rxBytes":10,"txBytes":20},"ephemeral-storage":{"usedBytes":100}},` +
+				`{"podRef":{"name":"pod-system","namespace":"kube-system"},"ephemeral-storage":{"usedBytes":500}},` +
+				`{"podRef":{"name":"pod-large","namespace":"default"},"ephemeral-storage":{"usedBytes":900},"volume":[{"name":"data"}]}` +
+				`]}`))
+		}))
+		toolResult, err := c.callTool("nodes_stats_summary", map[string]interface{}{"name": "node-1", "namespace": "default"})
+		t.Run("nodes_stats_summary returns the node and pod usage", func(t *testing.T) {
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			var summary kubernetes.NodesStatsSummary
+			if err = yaml.Unmarshal([]byte(toolResult.Content[0].(mcp.TextContent).Text), &summary); err != nil {
+				t.Fatalf("invalid tool result content %v", err)
+			}
+			if summary.Node.NodeName != "node-1" || *summary.Node.Fs.UsedBytes != 2000 {
+				t.Errorf("unexpected node stats, got %v", summary.Node)
+			}
+			if len(summary.Pods) != 2 {
+				t.Fatalf("expected the 2 pods in default, got %v", summary.Pods)
+			}
+			if summary.Pods[0].PodRef.Name != "pod-large" || summary.Pods[1].PodRef.Name != "pod-small" {
+				t.Errorf("expected pods sorted by ephemeral storage usage, got %v", summary.Pods)
+			}
+			if *summary.Pods[1].Network.RxBytes != 10 || *summary.Pods[1].Network.TxBytes != 20 {
+				t.Errorf("unexpected pod network stats, got %v", summary.Pods[1].Network)
+			}
+		})
+	})
+}
+
+func TestNodesProxyDenied(t *testing.T) {
+	deniedResourcesServer := &config.StaticConfig{DeniedResources: []config.GroupVersionKind{{Version: "v1", Kind: "Node"}}}
+	testCaseWithContext(t, &mcpContext{staticConfig: deniedResourcesServer}, func(c *mcpContext) {
+		mockServer := NewMockServer()
+		defer mockServer.Close()
+		c.withKubeConfig(mockServer.config)
+		nodesLog, _ := c.callTool("nodes_log", map[string]interface{}{"name": "node-1", "query": "kubelet"})
+		t.Run("nodes_log describes denial", func(t *testing.T) {
+			expectedMessage := "failed to get node node-1 log: resource not allowed: /v1, Kind=Node"
+			if !nodesLog.IsError || nodesLog.Content[0].(mcp.TextContent).Text != expectedMessage {
+				t.Fatalf("expected descriptive error '%s', got %v", expectedMessage, nodesLog.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		nodesStatsSummary, _ := c.callTool("nodes_stats_summary", map[string]interface{}{"name": "node-1"})
+		t.Run("nodes_stats_summary describes denial", func(t *testing.T) {
+			expectedMessage := "failed to get node node-1 stats summary: resource not allowed: /v1, Kind=Node"
+			if !nodesStatsSummary.IsError || nodesStatsSummary.Content[0].(mcp.TextContent).Text != expectedMessage {
+				t.Fatalf("expected descriptive error '%s', got %v", expectedMessage, nodesStatsSummary.Content[0].(mcp.TextContent).Text)
+			}
+		})
+	})
+}
@@ -0,0 +1,64 @@
+package mcp
+
+import (
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/manusa/kubernetes-mcp-server/pkg/kubernetes"
+)
+
+func withListLimit() mcp.ToolOption {
+	return mcp.WithNumber("limit",
+		mcp.Description("Optional maximum number of items to return. If there are more items, the response includes a continue token to retrieve the next page"),
+		mcp.Min(1),
+	)
+}
+
+func withListContinue() mcp.ToolOption {
+	return mcp.WithString("continue",
+		mcp.Description("Optional continue token returned by a previous call to retrieve the next page of items (the rest of the arguments must not change)"),
+	)
+}
+
+// paginate sets the limit and continue list options from the tool arguments, the limit is capped by the configured max_list_items
+func (s *Server) paginate(arguments map[string]interface{}, options *kubernetes.ResourceListOptions) error {
+	if limit := arguments["limit"]; limit != nil {
+		l, ok := limit.(float64)
+		if !ok || l < 1 {
+			return fmt.Errorf("limit is not a positive number")
+		}
+		options.Limit = int64(l)
+	}
+	if continueToken := arguments["continue"]; continueToken != nil {
+		c, ok := continueToken.(string)
+		if !ok {
+			return fmt.Errorf("continue is not a string")
+		}
+		options.Continue = c
+	}
+	if maxListItems := int64(s.configuration.StaticConfig.MaxListItems); maxListItems > 0 && (options.Limit == 0 || options.Limit > maxListItems) {
+		options.Limit = maxListItems
+	}
+	return nil
+}
+
+// printList prints the list with the configured output and, if the list is incomplete, how to retrieve the next page
+func (s *Server) printList(ret runtime.Unstructured) (string, error) {
+	printed, err := s.configuration.ListOutput.PrintObj(ret)
+	if err != nil {
+		return "", err
+	}
+	continueToken, _, _ := unstructured.NestedString(ret.UnstructuredContent(), "metadata", "continue")
+	if continueToken == "" {
+		return printed, nil
+	}
+	remaining := "more items"
+	if remainingItemCount, found, _ := unstructured.NestedInt64(ret.UnstructuredContent(), "metadata", "remainingItemCount"); found {
+		remaining = fmt.Sprintf("%d more items", remainingItemCount)
+	}
+	return fmt.Sprintf("%s\n# The list is incomplete, there are %s. To retrieve the next page, call the tool again with the same arguments and continue: %s\n",
+		printed, remaining, continueToken), nil
+}
@@ -0,0 +1,91 @@
+package mcp
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+
+	"github.com/manusa/kubernetes-mcp-server/pkg/config"
+)
+
+func TestPagination(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		c.withEnvTest()
+		firstPage, err := c.callTool("namespaces_list", map[string]interface{}{"limit": 1})
+		t.Run("namespaces_list with limit returns first page", func(t *testing.T) {
+			if err != nil || firstPage.IsError {
+				t.Fatalf("call tool failed %v %v", err, firstPage.Content)
+			}
+			var decoded []unstructured.Unstructured
+			if err := yaml.Unmarshal([]byte(firstPage.Content[0].(mcp.TextContent).Text), &decoded); err != nil {
+				t.Fatalf("invalid tool result content %v", err)
+			}
+			if len(decoded) != 1 {
+				t.Fatalf("invalid namespace count, expected 1, got %v", len(decoded))
+			}
+		})
+		continueToken := regexp.MustCompile(`continue: (\S+)`).FindStringSubmatch(firstPage.Content[0].(mcp.TextContent).Text)
+		t.Run("namespaces_list with limit returns continue token and remaining items", func(t *testing.T) {
+			if len(continueToken) != 2 {
+				t.Fatalf("expected continue token, got %v", firstPage.Content[0].(mcp.TextContent).Text)
+			}
+			if !regexp.MustCompile(`there are \d+ more items`).MatchString(firstPage.Content[0].(mcp.TextContent).Text) {
+				t.Fatalf("expected remaining item count, got %v", firstPage.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		secondPage, err := c.callTool("namespaces_list", map[string]interface{}{"limit": 1, "continue": continueToken[1]})
+		t.Run("namespaces_list with continue returns next page", func(t *testing.T) {
+			if err != nil || secondPage.IsError {
+				t.Fatalf("call tool failed %v %v", err, secondPage.Content)
+			}
+			var first, second []unstructured.Unstructured
+			_ = yaml.Unmarshal([]byte(firstPage.Content[0].(mcp.TextContent).Text), &first)
+			_ = yaml.Unmarshal([]byte(secondPage.Content[0].(mcp.TextContent).Text), &second)
+			if len(second) != 1 || second[0].GetName() == first[0].GetName() {
+				t.Fatalf("invalid next page, got %v", secondPage.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("namespaces_list with invalid continue returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("namespaces_list", map[string]interface{}{"continue": "invalid"})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+		})
+		t.Run("resources_list without limit returns complete list", func(t *testing.T) {
+			toolResult, _ := c.callTool("resources_list", map[string]interface{}{"apiVersion": "v1", "kind": "Namespace"})
+			if toolResult.IsError || strings.Contains(toolResult.Content[0].(mcp.TextContent).Text, "The list is incomplete") {
+				t.Fatalf("expected complete list, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("pods_list with limit returns continue token", func(t *testing.T) {
+			toolResult, _ := c.callTool("pods_list", map[string]interface{}{"limit": 1})
+			if toolResult.IsError || !strings.Contains(toolResult.Content[0].(mcp.TextContent).Text, "The list is incomplete") {
+				t.Fatalf("expected incomplete list, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+	})
+}
+
+func TestPaginationMaxListItems(t *testing.T) {
+	testCaseWithContext(t, &mcpContext{staticConfig: &config.StaticConfig{MaxListItems: 1}}, func(c *mcpContext) {
+		c.withEnvTest()
+		toolResult, err := c.callTool("resources_list", map[string]interface{}{"apiVersion": "v1", "kind": "Namespace", "limit": 100})
+		t.Run("resources_list limit is capped by max_list_items", func(t *testing.T) {
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			var decoded []unstructured.Unstructured
+			_ = yaml.Unmarshal([]byte(toolResult.Content[0].(mcp.TextContent).Text), &decoded)
+			if len(decoded) != 1 {
+				t.Fatalf("invalid namespace count, expected 1, got %v", len(decoded))
+			}
+			if !strings.Contains(toolResult.Content[0].(mcp.TextContent).Text, "The list is incomplete") {
+				t.Fatalf("expected incomplete list, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+	})
+}
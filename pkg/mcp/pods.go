@@ -3,22 +3,56 @@ package mcp
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/kubectl/pkg/metricsutil"
+	"k8s.io/utils/ptr"
 
 	"github.com/manusa/kubernetes-mcp-server/pkg/kubernetes"
 	"github.com/manusa/kubernetes-mcp-server/pkg/output"
 )
 
+const (
+	defaultPodsLogTail       = 256
+	defaultPodsLogFollowTail = 10
+	defaultLogsAggregateTail = 100
+	// defaultPodsExecTimeout and defaultPodsExecMaxOutputBytes bound the execution time and the output captured from each stream by pods_exec
+	defaultPodsExecTimeout        = 60
+	defaultPodsExecMaxOutputBytes = 64 * 1024
+	defaultPodsDebugTimeout       = 60
+	// defaultPodsDebugNodeTTL is the default number of seconds after which the privileged Node debug Pods are deleted by the run sandbox janitor
+	defaultPodsDebugNodeTTL = 3600
+	// defaultPodsCpMaxBytes and defaultPodsCpTimeout bound the size of the files copied from or to a Pod with pods_cp_from and pods_cp_to,
+	// and the time to copy them
+	defaultPodsCpMaxBytes = 1024 * 1024
+	defaultPodsCpTimeout  = 60
+	// defaultPodsLogFollowSeconds and maxPodsLogFollowSeconds bound the time a pods_log call in follow mode keeps the stream open
+	defaultPodsLogFollowSeconds = 30
+	maxPodsLogFollowSeconds     = 300
+	defaultPodsLogFollowLines   = 500
+)
+
 func (s *Server) initPods() []server.ServerTool {
 	return []server.ServerTool{
 		{Tool: mcp.NewTool("pods_list",
 			mcp.WithDescription("List all the Kubernetes pods in the current cluster from all namespaces"),
 			mcp.WithString("labelSelector", mcp.Description("Optional Kubernetes label selector (e.g. 'app=myapp,env=prod' or 'app in (myapp,yourapp)'), use this option when you want to filter the pods by label"), mcp.Pattern("([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]")),
+			mcp.WithString("fieldSelector", mcp.Description("Optional Kubernetes field selector (e.g. 'status.phase=Running' or 'spec.nodeName=worker-1'), use this option when you want to filter the pods by field")),
+			withListLimit(),
+			withListContinue(),
 			// Tool annotations
 			mcp.WithTitleAnnotation("Pods: List"),
 			mcp.WithReadOnlyHintAnnotation(true),
@@ -29,6 +63,9 @@ func (s *Server) initPods() []server.ServerTool {
 			mcp.WithDescription("List all the Kubernetes pods in the specified namespace in the current cluster"),
 			mcp.WithString("namespace", mcp.Description("Namespace to list pods from"), mcp.Required()),
 			mcp.WithString("labelSelector", mcp.Description("Optional Kubernetes label selector (e.g. 'app=myapp,env=prod' or 'app in (myapp,yourapp)'), use this option when you want to filter the pods by label"), mcp.Pattern("([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]")),
+			mcp.WithString("fieldSelector", mcp.Description("Optional Kubernetes field selector (e.g. 'status.phase=Running' or 'spec.nodeName=worker-1'), use this option when you want to filter the pods by field")),
+			withListLimit(),
+			withListContinue(),
 			// Tool annotations
 			mcp.WithTitleAnnotation("Pods: List in Namespace"),
 			mcp.WithReadOnlyHintAnnotation(true),
@@ -87,6 +124,9 @@ func (s *Server) initPods() []server.ServerTool {
 				mcp.Required(),
 			),
 			mcp.WithString("container", mcp.Description("Name of the Pod container where the command will be executed (Optional)")),
+			mcp.WithString("stdin", mcp.Description("Content to send to the standard input of the command (Optional)")),
+			mcp.WithNumber("timeout", mcp.Description(fmt.Sprintf("Maximum number of seconds to wait for the command to complete, the output captured so far is returned if exceeded (Optional, %d if not provided)", defaultPodsExecTimeout)), mcp.Min(1)),
+			mcp.WithNumber("maxOutputBytes", mcp.Description(fmt.Sprintf("Maximum number of bytes captured from each of the stdout and stderr streams, the rest of the output is truncated (Optional, %d if not provided)", defaultPodsExecMaxOutputBytes)), mcp.Min(1)),
 			// Tool annotations
 			mcp.WithTitleAnnotation("Pods: Exec"),
 			mcp.WithReadOnlyHintAnnotation(false),
@@ -94,23 +134,144 @@ func (s *Server) initPods() []server.ServerTool {
 			mcp.WithIdempotentHintAnnotation(false),
 			mcp.WithOpenWorldHintAnnotation(true),
 		), Handler: s.podsExec},
+		{Tool: mcp.NewTool("pods_debug",
+			mcp.WithDescription("Debug a Kubernetes Pod by adding an ephemeral debug container to it (useful for distroless images without a shell), "+
+				"or debug a Kubernetes Node by creating a privileged Pod on it that shares the host namespaces and mounts the host filesystem at /host. "+
+				"The tool waits until the debug container is running and optionally executes a command in it, "+
+				"further commands can be executed in the debug container with pods_exec. "+
+				"Ephemeral containers can't be removed from the Pod, Node debug Pods should be deleted with pods_delete once the debugging session is over "+
+				"(they're deleted automatically once their ttl expires)"),
+			mcp.WithString("namespace", mcp.Description("Namespace of the Pod to debug, or where the Node debug Pod is created (Optional, current namespace if not provided)")),
+			mcp.WithString("name", mcp.Description("Name of the Pod to debug (required if no node is provided)")),
+			mcp.WithString("node", mcp.Description("Name of the Node to debug (required if no Pod name is provided)")),
+			mcp.WithString("image", mcp.Description(fmt.Sprintf("Container image of the debug container (Optional, %s if not provided)", kubernetes.DefaultDebugImage))),
+			mcp.WithString("target", mcp.Description("Name of the Pod container to target, the debug container shares its process namespace (Optional, Pods only)")),
+			mcp.WithArray("command", mcp.Description("Command to execute in the debug container once it's running (Optional). "+
+				`Example: ["ps", "aux"]`),
+				func(schema map[string]interface{}) {
+					schema["type"] = "array"
+					schema["items"] = map[string]interface{}{
+						"type": "string",
+					}
+				},
+			),
+			mcp.WithNumber("timeout", mcp.Description(fmt.Sprintf("Maximum number of seconds to wait for the debug container to be running, also applied to the command execution (Optional, %d if not provided)", defaultPodsDebugTimeout)), mcp.Min(1)),
+			mcp.WithNumber("ttl", mcp.Description(fmt.Sprintf("Number of seconds after which the Node debug Pod is deleted automatically (Optional, Nodes only, %d if not provided)", defaultPodsDebugNodeTTL)), mcp.Min(1)),
+			// Tool annotations
+			mcp.WithTitleAnnotation("Pods: Debug"),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(true),
+			mcp.WithIdempotentHintAnnotation(false),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.podsDebug},
+		{Tool: mcp.NewTool("pods_cp_from",
+			mcp.WithDescription("Copy a file out of a Kubernetes Pod container in the current or provided namespace (same as kubectl cp, the tar binary must be available in the container), "+
+				"or list the content of a directory of the container. Binary files are returned base64 encoded"),
+			mcp.WithString("namespace", mcp.Description("Namespace of the Pod (Optional, current namespace if not provided)")),
+			mcp.WithString("name", mcp.Description("Name of the Pod"), mcp.Required()),
+			mcp.WithString("container", mcp.Description("Name of the Pod container (Optional, first container if not provided)")),
+			mcp.WithString("path", mcp.Description("Absolute path of the file to copy, or of the directory to list, in the container (e.g. /etc/nginx/nginx.conf)"), mcp.Required()),
+			mcp.WithBoolean("list", mcp.Description("List the content of the directory at path (ls -la) instead of copying a file (Optional)")),
+			mcp.WithString("encoding", mcp.Description("Encoding of the returned file content (Optional, text unless the file isn't valid UTF-8 text, base64 otherwise)"), mcp.Enum(podsCpEncodings...)),
+			mcp.WithNumber("maxBytes", mcp.Description(fmt.Sprintf("Maximum size in bytes of the file to copy, larger files are rejected (Optional, %d if not provided)", defaultPodsCpMaxBytes)), mcp.Min(1)),
+			mcp.WithNumber("timeout", mcp.Description(fmt.Sprintf("Maximum number of seconds to wait for the copy to complete (Optional, %d if not provided)", defaultPodsCpTimeout)), mcp.Min(1)),
+			// Tool annotations
+			mcp.WithTitleAnnotation("Pods: Copy From"),
+			mcp.WithReadOnlyHintAnnotation(false), // Executes tar or ls in the container, gated as pods_exec
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.podsCpFrom},
+		{Tool: mcp.NewTool("pods_cp_to",
+			mcp.WithDescription("Copy a file into a Kubernetes Pod container in the current or provided namespace (same as kubectl cp, the tar binary must be available in the container). "+
+				"The parent directory must exist and an existing file is overwritten"),
+			mcp.WithString("namespace", mcp.Description("Namespace of the Pod (Optional, current namespace if not provided)")),
+			mcp.WithString("name", mcp.Description("Name of the Pod"), mcp.Required()),
+			mcp.WithString("container", mcp.Description("Name of the Pod container (Optional, first container if not provided)")),
+			mcp.WithString("path", mcp.Description("Absolute path of the file to write in the container (e.g. /tmp/script.sh)"), mcp.Required()),
+			mcp.WithString("content", mcp.Description("Content of the file"), mcp.Required()),
+			mcp.WithString("encoding", mcp.Description("Encoding of the provided content, use base64 for binary files (Optional, text if not provided)"), mcp.Enum(podsCpEncodings...)),
+			mcp.WithString("mode", mcp.Description("Octal permissions of the file, e.g. 0755 for executable scripts (Optional, 0644 if not provided)"), mcp.Pattern("^0?[0-7]{3}$")),
+			mcp.WithNumber("maxBytes", mcp.Description(fmt.Sprintf("Maximum size in bytes of the decoded content, larger contents are rejected (Optional, %d if not provided)", defaultPodsCpMaxBytes)), mcp.Min(1)),
+			mcp.WithNumber("timeout", mcp.Description(fmt.Sprintf("Maximum number of seconds to wait for the copy to complete (Optional, %d if not provided)", defaultPodsCpTimeout)), mcp.Min(1)),
+			// Tool annotations
+			mcp.WithTitleAnnotation("Pods: Copy To"),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(true), // Overwrites the existing file
+			mcp.WithIdempotentHintAnnotation(true),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.podsCpTo},
 		{Tool: mcp.NewTool("pods_log",
 			mcp.WithDescription("Get the logs of a Kubernetes Pod in the current or provided namespace with the provided name"),
 			mcp.WithString("namespace", mcp.Description("Namespace to get the Pod logs from")),
 			mcp.WithString("name", mcp.Description("Name of the Pod to get the logs from"), mcp.Required()),
-			mcp.WithString("container", mcp.Description("Name of the Pod container to get the logs from (Optional)")),
+			mcp.WithString("container", mcp.Description("Name of the Pod container to get the logs from (Optional, required for Pods with multiple containers unless a default container is annotated)")),
+			mcp.WithBoolean("previous", mcp.Description("Return the logs of the previous terminated container instance, useful to investigate crashes (Optional)")),
+			mcp.WithNumber("sinceSeconds", mcp.Description("Only return logs newer than the provided number of seconds (Optional, can't be combined with sinceTime)"), mcp.Min(1)),
+			mcp.WithString("sinceTime", mcp.Description("Only return logs after the provided RFC3339 timestamp, e.g. 2025-01-01T10:00:00Z (Optional, can't be combined with sinceSeconds)")),
+			mcp.WithNumber("tail", mcp.Description(fmt.Sprintf("Number of lines to return from the end of the logs (Optional, %d if not provided, or %d when following the logs, -1 to return all the lines)", defaultPodsLogTail, defaultPodsLogFollowTail))),
+			mcp.WithBoolean("timestamps", mcp.Description("Prefix each log line with its RFC3339 timestamp (Optional)")),
+			mcp.WithNumber("limitBytes", mcp.Description("Maximum number of bytes of logs to return (Optional)"), mcp.Min(1)),
+			mcp.WithString("grep", mcp.Description("Regular expression to filter the log lines, only the matching lines are returned, e.g. '(?i)error|exception' (Optional)")),
+			mcp.WithBoolean("follow", mcp.Description("Follow the logs, new lines are sent to the client as progress notifications (or logging notifications if the request has no progress token) "+
+				"until followSeconds or followLines is reached, the container terminates, or the request is cancelled. The followed lines are also returned in the result (Optional)")),
+			mcp.WithNumber("followSeconds", mcp.Description(fmt.Sprintf("Maximum number of seconds to follow the logs (Optional, %d if not provided, at most %d)", defaultPodsLogFollowSeconds, maxPodsLogFollowSeconds)),
+				mcp.Min(1), mcp.Max(maxPodsLogFollowSeconds)),
+			mcp.WithNumber("followLines", mcp.Description(fmt.Sprintf("Maximum number of lines to follow (Optional, %d if not provided)", defaultPodsLogFollowLines)), mcp.Min(1)),
 			// Tool annotations
 			mcp.WithTitleAnnotation("Pods: Log"),
 			mcp.WithReadOnlyHintAnnotation(true),
 			mcp.WithDestructiveHintAnnotation(false),
 			mcp.WithOpenWorldHintAnnotation(true),
 		), Handler: s.podsLog},
+		{Tool: mcp.NewTool("logs_aggregate",
+			mcp.WithDescription("Get the logs of all the containers of the Kubernetes Pods matching a label selector or owned by a workload (Deployment, StatefulSet, DaemonSet, ReplicaSet, or Job) in the current or provided namespace. "+
+				"Every line is prefixed with [pod/container] and the lines of all the containers are merged by timestamp"),
+			mcp.WithString("namespace", mcp.Description("Namespace of the Pods (Optional, current namespace if not provided)")),
+			mcp.WithString("labelSelector", mcp.Description("Kubernetes label selector of the Pods (e.g. 'app=myapp,env=prod'), required if no owner is provided"), mcp.Pattern("([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]")),
+			mcp.WithString("ownerKind", mcp.Description("Kind of the workload that owns the Pods, required if no labelSelector is provided"), mcp.Enum(slices.Sorted(maps.Keys(kubernetes.PodsLogOwnerKinds))...)),
+			mcp.WithString("ownerName", mcp.Description("Name of the workload that owns the Pods, required if ownerKind is provided")),
+			mcp.WithString("container", mcp.Description("Name of the container to get the logs from (Optional, all the containers if not provided)")),
+			mcp.WithNumber("sinceSeconds", mcp.Description("Only return logs newer than the provided number of seconds (Optional, can't be combined with sinceTime)"), mcp.Min(1)),
+			mcp.WithString("sinceTime", mcp.Description("Only return logs after the provided RFC3339 timestamp, e.g. 2025-01-01T10:00:00Z (Optional, can't be combined with sinceSeconds)")),
+			mcp.WithNumber("tail", mcp.Description(fmt.Sprintf("Number of lines to return from the end of the logs of each container (Optional, %d if not provided, -1 to return all the lines)", defaultLogsAggregateTail))),
+			mcp.WithBoolean("timestamps", mcp.Description("Include the RFC3339 timestamp of each log line after the [pod/container] prefix (Optional)")),
+			mcp.WithString("grep", mcp.Description("Regular expression to filter the log lines, only the matching lines are returned, e.g. '(?i)error|exception' (Optional)")),
+			// Tool annotations
+			mcp.WithTitleAnnotation("Logs: Aggregate"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.logsAggregate},
 		{Tool: mcp.NewTool("pods_run",
 			mcp.WithDescription("Run a Kubernetes Pod in the current or provided namespace with the provided container image and optional name"),
 			mcp.WithString("namespace", mcp.Description("Namespace to run the Pod in")),
 			mcp.WithString("name", mcp.Description("Name of the Pod (Optional, random name if not provided)")),
 			mcp.WithString("image", mcp.Description("Container Image to run in the Pod"), mcp.Required()),
 			mcp.WithNumber("port", mcp.Description("TCP/IP port to expose from the Pod container (Optional, no port exposed if not provided)")),
+			mcp.WithArray("command", mcp.Description("Entrypoint of the container, replaces the image ENTRYPOINT (Optional)"),
+				func(schema map[string]interface{}) {
+					schema["type"] = "array"
+					schema["items"] = map[string]interface{}{
+						"type": "string",
+					}
+				},
+			),
+			mcp.WithArray("args", mcp.Description("Arguments of the entrypoint, replace the image CMD (Optional)"),
+				func(schema map[string]interface{}) {
+					schema["type"] = "array"
+					schema["items"] = map[string]interface{}{
+						"type": "string",
+					}
+				},
+			),
+			mcp.WithObject("env", mcp.Description("Environment variables of the container (Optional), e.g. {\"LOG_LEVEL\": \"debug\"}")),
+			mcp.WithObject("requests", mcp.Description("Resource requests of the container (Optional), e.g. {\"cpu\": \"100m\", \"memory\": \"128Mi\"}")),
+			mcp.WithObject("limits", mcp.Description("Resource limits of the container (Optional), e.g. {\"cpu\": \"500m\", \"memory\": \"256Mi\"}")),
+			mcp.WithString("serviceAccount", mcp.Description("Name of the ServiceAccount to run the Pod as (Optional, default ServiceAccount if not provided)")),
+			mcp.WithString("restartPolicy", mcp.Description("Restart policy of the Pod (Optional, Always if not provided), use Never or OnFailure to run tasks to completion"),
+				mcp.Enum(string(corev1.RestartPolicyAlways), string(corev1.RestartPolicyOnFailure), string(corev1.RestartPolicyNever))),
+			mcp.WithObject("nodeSelector", mcp.Description("Node labels the Pod must be scheduled on (Optional), e.g. {\"kubernetes.io/os\": \"linux\"}")),
+			mcp.WithNumber("ttl", mcp.Description("Number of seconds after which the Pod and the exposed Service (and Route) are deleted automatically (Optional, never deleted automatically if not provided)"), mcp.Min(1)),
 			// Tool annotations
 			mcp.WithTitleAnnotation("Pods: Run"),
 			mcp.WithReadOnlyHintAnnotation(false),
@@ -129,11 +290,17 @@ func (s *Server) podsListInAllNamespaces(ctx context.Context, ctr mcp.CallToolRe
 	if labelSelector != nil {
 		resourceListOptions.ListOptions.LabelSelector = labelSelector.(string)
 	}
+	if fieldSelector, ok := ctr.GetArguments()["fieldSelector"].(string); ok {
+		resourceListOptions.ListOptions.FieldSelector = fieldSelector
+	}
+	if err := s.paginate(ctr.GetArguments(), &resourceListOptions); err != nil {
+		return NewTextResult("", fmt.Errorf("failed to list pods in all namespaces, %s", err)), nil
+	}
 	ret, err := s.k.Derived(ctx).PodsListInAllNamespaces(ctx, resourceListOptions)
 	if err != nil {
 		return NewTextResult("", fmt.Errorf("failed to list pods in all namespaces: %v", err)), nil
 	}
-	return NewTextResult(s.configuration.ListOutput.PrintObj(ret)), nil
+	return NewTextResult(s.printList(ret)), nil
 }
 
 func (s *Server) podsListInNamespace(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -148,11 +315,17 @@ func (s *Server) podsListInNamespace(ctx context.Context, ctr mcp.CallToolReques
 	if labelSelector != nil {
 		resourceListOptions.ListOptions.LabelSelector = labelSelector.(string)
 	}
+	if fieldSelector, ok := ctr.GetArguments()["fieldSelector"].(string); ok {
+		resourceListOptions.ListOptions.FieldSelector = fieldSelector
+	}
+	if err := s.paginate(ctr.GetArguments(), &resourceListOptions); err != nil {
+		return NewTextResult("", fmt.Errorf("failed to list pods in namespace %s, %s", ns, err)), nil
+	}
 	ret, err := s.k.Derived(ctx).PodsListInNamespace(ctx, ns.(string), resourceListOptions)
 	if err != nil {
 		return NewTextResult("", fmt.Errorf("failed to list pods in namespace %s: %v", ns, err)), nil
 	}
-	return NewTextResult(s.configuration.ListOutput.PrintObj(ret)), nil
+	return NewTextResult(s.printList(ret)), nil
 }
 
 func (s *Server) podsGet(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -228,23 +401,249 @@ func (s *Server) podsExec(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.Ca
 		container = ""
 	}
 	commandArg := ctr.GetArguments()["command"]
-	command := make([]string, 0)
+	options := kubernetes.PodsExecOptions{
+		Container:      container.(string),
+		Command:        make([]string, 0),
+		Timeout:        defaultPodsExecTimeout * time.Second,
+		MaxOutputBytes: defaultPodsExecMaxOutputBytes,
+	}
 	if _, ok := commandArg.([]interface{}); ok {
 		for _, cmd := range commandArg.([]interface{}) {
 			if _, ok := cmd.(string); ok {
-				command = append(command, cmd.(string))
+				options.Command = append(options.Command, cmd.(string))
 			}
 		}
 	} else {
 		return NewTextResult("", errors.New("failed to exec in pod, invalid command argument")), nil
 	}
-	ret, err := s.k.Derived(ctx).PodsExec(ctx, ns.(string), name.(string), container.(string), command)
+	if v, ok := ctr.GetArguments()["stdin"].(string); ok && v != "" {
+		options.Stdin = strings.NewReader(v)
+	}
+	if v, ok := ctr.GetArguments()["timeout"].(float64); ok {
+		if v < 1 {
+			return NewTextResult("", errors.New("failed to exec in pod, timeout must be a positive number")), nil
+		}
+		options.Timeout = time.Duration(v * float64(time.Second))
+	}
+	if v, ok := ctr.GetArguments()["maxOutputBytes"].(float64); ok {
+		if v < 1 {
+			return NewTextResult("", errors.New("failed to exec in pod, maxOutputBytes must be a positive number")), nil
+		}
+		options.MaxOutputBytes = int(v)
+	}
+	ret, err := s.k.Derived(ctx).PodsExec(ctx, ns.(string), name.(string), options)
 	if err != nil {
 		return NewTextResult("", fmt.Errorf("failed to exec in pod %s in namespace %s: %v", name, ns, err)), nil
-	} else if ret == "" {
-		ret = fmt.Sprintf("The executed command in pod %s in namespace %s has not produced any output", name, ns)
 	}
-	return NewTextResult(ret, err), nil
+	return NewTextResult(podsExecOutput(ret, options), nil), nil
+}
+
+// podsExecOutput returns the exit status of the executed command followed by its stdout and stderr in separate sections
+func podsExecOutput(ret *kubernetes.PodsExecResult, options kubernetes.PodsExecOptions) string {
+	var out strings.Builder
+	if ret.TimedOut {
+		out.WriteString(fmt.Sprintf("# The command didn't complete in %s, the output captured until then follows\n", options.Timeout))
+	} else {
+		out.WriteString(fmt.Sprintf("# The command exited with code %d\n", ret.ExitCode))
+	}
+	if ret.Stdout == "" && ret.Stderr == "" {
+		out.WriteString("The command has not produced any output\n")
+	}
+	section := func(title, content string, truncated bool) {
+		if content == "" {
+			return
+		}
+		out.WriteString("# " + title + "\n" + content)
+		if !strings.HasSuffix(content, "\n") {
+			out.WriteString("\n")
+		}
+		if truncated {
+			out.WriteString(fmt.Sprintf("[... %s truncated, exceeded %d bytes]\n", title, options.MaxOutputBytes))
+		}
+	}
+	section("stdout", ret.Stdout, ret.StdoutTruncated)
+	section("stderr", ret.Stderr, ret.StderrTruncated)
+	return out.String()
+}
+
+func (s *Server) podsDebug(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ns, _ := ctr.GetArguments()["namespace"].(string)
+	name, _ := ctr.GetArguments()["name"].(string)
+	node, _ := ctr.GetArguments()["node"].(string)
+	if name == "" && node == "" {
+		return NewTextResult("", errors.New("failed to debug, missing argument name or node")), nil
+	} else if name != "" && node != "" {
+		return NewTextResult("", errors.New("failed to debug, only one of name or node can be provided")), nil
+	}
+	options := kubernetes.PodsDebugOptions{
+		Timeout:     defaultPodsDebugTimeout * time.Second,
+		ExecOptions: kubernetes.PodsExecOptions{MaxOutputBytes: defaultPodsExecMaxOutputBytes},
+		TTL:         defaultPodsDebugNodeTTL * time.Second,
+	}
+	options.Image, _ = ctr.GetArguments()["image"].(string)
+	options.TargetContainer, _ = ctr.GetArguments()["target"].(string)
+	if commandArg, ok := ctr.GetArguments()["command"].([]interface{}); ok {
+		for _, cmd := range commandArg {
+			if c, ok := cmd.(string); ok {
+				options.Command = append(options.Command, c)
+			}
+		}
+	}
+	if v, ok := ctr.GetArguments()["timeout"].(float64); ok {
+		if v < 1 {
+			return NewTextResult("", errors.New("failed to debug, timeout must be a positive number")), nil
+		}
+		options.Timeout = time.Duration(v * float64(time.Second))
+	}
+	options.ExecOptions.Timeout = options.Timeout
+	if v, ok := ctr.GetArguments()["ttl"].(float64); ok {
+		if v < 1 {
+			return NewTextResult("", errors.New("failed to debug, ttl must be a positive number")), nil
+		}
+		options.TTL = time.Duration(v * float64(time.Second))
+	}
+	k := s.k.Derived(ctx)
+	var ret *kubernetes.PodsDebugResult
+	var err error
+	if node != "" {
+		ret, err = k.PodsDebugNode(ctx, ns, node, options)
+	} else {
+		ret, err = k.PodsDebug(ctx, ns, name, options)
+	}
+	if err != nil && ret == nil && node != "" {
+		return NewTextResult("", fmt.Errorf("failed to debug node %s: %v", node, err)), nil
+	} else if err != nil && ret == nil {
+		return NewTextResult("", fmt.Errorf("failed to debug pod %s in namespace %s: %v", name, ns, err)), nil
+	}
+	var out string
+	if node != "" {
+		out = fmt.Sprintf("# Debug pod %s created in namespace %s on node %s, the host filesystem is mounted at /host in container %s.\n"+
+			"# Delete the pod with pods_delete once the debugging session is over, otherwise it's deleted automatically after %s\n",
+			ret.Pod, ret.Namespace, node, ret.Container, options.TTL)
+	} else {
+		out = fmt.Sprintf("# Ephemeral debug container %s added to pod %s in namespace %s\n", ret.Container, ret.Pod, ret.Namespace)
+	}
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("%sfailed to debug: %v", out, err)), nil
+	}
+	if ret.Exec != nil {
+		out += podsExecOutput(ret.Exec, options.ExecOptions)
+	} else {
+		out += fmt.Sprintf("# Execute commands in the debug container with pods_exec (namespace: %s, name: %s, container: %s)\n", ret.Namespace, ret.Pod, ret.Container)
+	}
+	return NewTextResult(out, nil), nil
+}
+
+// podsCpEncodings are the supported encodings of the file contents copied from or to a Pod
+var podsCpEncodings = []string{"text", "base64"}
+
+// podsCpArguments returns the namespace, name, container, and path arguments of the pods_cp_from and pods_cp_to tools
+func podsCpArguments(ctr mcp.CallToolRequest) (string, string, string, string, error) {
+	ns, _ := ctr.GetArguments()["namespace"].(string)
+	container, _ := ctr.GetArguments()["container"].(string)
+	name, ok := ctr.GetArguments()["name"].(string)
+	if !ok || name == "" {
+		return "", "", "", "", errors.New("missing argument name")
+	}
+	filePath, ok := ctr.GetArguments()["path"].(string)
+	if !ok || filePath == "" {
+		return "", "", "", "", errors.New("missing argument path")
+	}
+	return ns, name, container, filePath, nil
+}
+
+// podsCpTimeout returns the timeout argument of the pods_cp_from and pods_cp_to tools, or its default value
+func podsCpTimeout(ctr mcp.CallToolRequest) (time.Duration, error) {
+	v, ok := ctr.GetArguments()["timeout"].(float64)
+	if !ok {
+		return defaultPodsCpTimeout * time.Second, nil
+	}
+	if v < 1 {
+		return 0, errors.New("timeout must be a positive number")
+	}
+	return time.Duration(v * float64(time.Second)), nil
+}
+
+// podsCpMaxBytes returns the maxBytes argument of the pods_cp_from and pods_cp_to tools, or its default value
+func podsCpMaxBytes(ctr mcp.CallToolRequest) (int64, error) {
+	v, ok := ctr.GetArguments()["maxBytes"].(float64)
+	if !ok {
+		return defaultPodsCpMaxBytes, nil
+	}
+	if v < 1 {
+		return 0, errors.New("maxBytes must be a positive number")
+	}
+	return int64(v), nil
+}
+
+func (s *Server) podsCpFrom(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ns, name, container, filePath, err := podsCpArguments(ctr)
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to copy from pod, %s", err)), nil
+	}
+	maxBytes, err := podsCpMaxBytes(ctr)
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to copy from pod, %s", err)), nil
+	}
+	timeout, err := podsCpTimeout(ctr)
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to copy from pod, %s", err)), nil
+	}
+	if list, ok := ctr.GetArguments()["list"].(bool); ok && list {
+		ret, err := s.k.Derived(ctx).PodsListDirectory(ctx, ns, name, container, filePath, timeout)
+		if err != nil {
+			return NewTextResult("", fmt.Errorf("failed to list %s in pod %s in namespace %s: %v", filePath, name, ns, err)), nil
+		}
+		return NewTextResult("# Content of the directory "+filePath+"\n"+ret, nil), nil
+	}
+	ret, err := s.k.Derived(ctx).PodsCopyFrom(ctx, ns, name, container, filePath, maxBytes, timeout)
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to copy %s from pod %s in namespace %s: %v", filePath, name, ns, err)), nil
+	}
+	encoding, _ := ctr.GetArguments()["encoding"].(string)
+	if encoding == "base64" || (encoding == "" && !utf8.Valid(ret.Content)) {
+		return NewTextResult(fmt.Sprintf("# Content of %s (%d bytes, mode %04o, base64 encoded)\n%s",
+			filePath, ret.Size, ret.Mode, base64.StdEncoding.EncodeToString(ret.Content)), nil), nil
+	}
+	return NewTextResult(fmt.Sprintf("# Content of %s (%d bytes, mode %04o)\n%s", filePath, ret.Size, ret.Mode, ret.Content), nil), nil
+}
+
+func (s *Server) podsCpTo(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ns, name, container, filePath, err := podsCpArguments(ctr)
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to copy to pod, %s", err)), nil
+	}
+	maxBytes, err := podsCpMaxBytes(ctr)
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to copy to pod, %s", err)), nil
+	}
+	timeout, err := podsCpTimeout(ctr)
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to copy to pod, %s", err)), nil
+	}
+	contentArg, ok := ctr.GetArguments()["content"].(string)
+	if !ok {
+		return NewTextResult("", errors.New("failed to copy to pod, missing argument content")), nil
+	}
+	content := []byte(contentArg)
+	if encoding, _ := ctr.GetArguments()["encoding"].(string); encoding == "base64" {
+		if content, err = base64.StdEncoding.DecodeString(contentArg); err != nil {
+			return NewTextResult("", fmt.Errorf("failed to copy to pod, content is not valid base64: %v", err)), nil
+		}
+	}
+	if int64(len(content)) > maxBytes {
+		return NewTextResult("", fmt.Errorf("failed to copy to pod, content is %d bytes, exceeds the limit of %d bytes", len(content), maxBytes)), nil
+	}
+	mode := int64(0644)
+	if v, ok := ctr.GetArguments()["mode"].(string); ok && v != "" {
+		if mode, err = strconv.ParseInt(v, 8, 32); err != nil || mode < 0 || mode > 0777 {
+			return NewTextResult("", fmt.Errorf("failed to copy to pod, mode %s is not a valid octal file mode", v)), nil
+		}
+	}
+	if err = s.k.Derived(ctx).PodsCopyTo(ctx, ns, name, container, filePath, content, mode, timeout); err != nil {
+		return NewTextResult("", fmt.Errorf("failed to copy %s to pod %s in namespace %s: %v", filePath, name, ns, err)), nil
+	}
+	return NewTextResult(fmt.Sprintf("%d bytes copied to %s (mode %04o) in pod %s", len(content), filePath, mode, name), nil), nil
 }
 
 func (s *Server) podsLog(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -256,19 +655,170 @@ func (s *Server) podsLog(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.Cal
 	if name == nil {
 		return NewTextResult("", errors.New("failed to get pod log, missing argument name")), nil
 	}
-	container := ctr.GetArguments()["container"]
-	if container == nil {
-		container = ""
+	if follow, ok := ctr.GetArguments()["follow"].(bool); ok && follow {
+		return s.podsLogFollow(ctx, ctr, ns.(string), name.(string))
+	}
+	options, err := podsLogOptions(ctr.GetArguments(), defaultPodsLogTail)
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to get pod log, %s", err)), nil
 	}
-	ret, err := s.k.Derived(ctx).PodsLog(ctx, ns.(string), name.(string), container.(string))
+	ret, err := s.k.Derived(ctx).PodsLog(ctx, ns.(string), name.(string), options)
 	if err != nil {
 		return NewTextResult("", fmt.Errorf("failed to get pod %s log in namespace %s: %v", name, ns, err)), nil
+	} else if ret == "" && options.Grep != "" {
+		ret = fmt.Sprintf("The pod %s in namespace %s has not logged any message matching %s", name, ns, options.Grep)
 	} else if ret == "" {
 		ret = fmt.Sprintf("The pod %s in namespace %s has not logged any message yet", name, ns)
 	}
 	return NewTextResult(ret, err), nil
 }
 
+// podsLogFollow follows the logs of the Pod for a bounded time and number of lines, forwarding every new line to the client as a notification
+func (s *Server) podsLogFollow(ctx context.Context, ctr mcp.CallToolRequest, ns, name string) (*mcp.CallToolResult, error) {
+	options, err := podsLogOptions(ctr.GetArguments(), defaultPodsLogFollowTail)
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to follow pod log, %s", err)), nil
+	}
+	followSeconds := float64(defaultPodsLogFollowSeconds)
+	if v, ok := ctr.GetArguments()["followSeconds"].(float64); ok {
+		if v < 1 || v > maxPodsLogFollowSeconds {
+			return NewTextResult("", fmt.Errorf("failed to follow pod log, followSeconds must be between 1 and %d", maxPodsLogFollowSeconds)), nil
+		}
+		followSeconds = v
+	}
+	followLines := defaultPodsLogFollowLines
+	if v, ok := ctr.GetArguments()["followLines"].(float64); ok {
+		if v < 1 {
+			return NewTextResult("", errors.New("failed to follow pod log, followLines must be a positive number")), nil
+		}
+		followLines = int(v)
+	}
+	var progressToken mcp.ProgressToken
+	if ctr.Params.Meta != nil {
+		progressToken = ctr.Params.Meta.ProgressToken
+	}
+	followCtx, cancel := context.WithTimeout(ctx, time.Duration(followSeconds*float64(time.Second)))
+	defer cancel()
+	var lines strings.Builder
+	count := 0
+	err = s.k.Derived(ctx).PodsLogFollow(followCtx, ns, name, options, func(line string) bool {
+		count++
+		lines.WriteString(line + "\n")
+		s.notifyLogLine(ctx, progressToken, count, line)
+		return count < followLines
+	})
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to follow pod %s log in namespace %s: %v", name, ns, err)), nil
+	}
+	var reason string
+	switch {
+	case count >= followLines:
+		reason = fmt.Sprintf("the limit of %d lines was reached", followLines)
+	case ctx.Err() != nil:
+		reason = "the request was cancelled"
+	case followCtx.Err() != nil:
+		reason = fmt.Sprintf("the limit of %s was reached", time.Duration(followSeconds*float64(time.Second)))
+	default:
+		reason = "the log stream ended"
+	}
+	return NewTextResult(fmt.Sprintf("%s# Stopped following the pod %s log in namespace %s after %d lines, %s", lines.String(), name, ns, count, reason), nil), nil
+}
+
+// notifyLogLine sends a followed log line to the client as a progress notification if the request provided a progress token,
+// or as a logging notification otherwise. Notifications are best effort, failures (e.g. the client doesn't support logging) are ignored.
+func (s *Server) notifyLogLine(ctx context.Context, progressToken mcp.ProgressToken, count int, line string) {
+	if progressToken != nil {
+		_ = s.server.SendNotificationToClient(ctx, string(mcp.MethodNotificationProgress), map[string]any{
+			"progressToken": progressToken,
+			"progress":      count,
+			"message":       line,
+		})
+		return
+	}
+	_ = s.server.SendLogMessageToClient(ctx, mcp.NewLoggingMessageNotification(mcp.LoggingLevelInfo, "pods_log", line))
+}
+
+// podsLogOptions returns the log options from the pods_log tool arguments
+func podsLogOptions(arguments map[string]interface{}, defaultTail int) (kubernetes.PodsLogOptions, error) {
+	options := kubernetes.PodsLogOptions{}
+	if v, ok := arguments["container"].(string); ok {
+		options.Container = v
+	}
+	if v, ok := arguments["previous"].(bool); ok {
+		options.Previous = v
+	}
+	if v, ok := arguments["timestamps"].(bool); ok {
+		options.Timestamps = v
+	}
+	if v, ok := arguments["grep"].(string); ok {
+		options.Grep = v
+	}
+	tail := float64(defaultTail)
+	if v, ok := arguments["tail"].(float64); ok {
+		tail = v
+	}
+	if tail >= 0 {
+		options.TailLines = ptr.To(int64(tail))
+	}
+	if v, ok := arguments["limitBytes"].(float64); ok {
+		if v < 1 {
+			return options, errors.New("limitBytes must be a positive number")
+		}
+		options.LimitBytes = ptr.To(int64(v))
+	}
+	if v, ok := arguments["sinceSeconds"].(float64); ok {
+		if v < 1 {
+			return options, errors.New("sinceSeconds must be a positive number")
+		}
+		options.SinceSeconds = ptr.To(int64(v))
+	}
+	if v, ok := arguments["sinceTime"].(string); ok && v != "" {
+		if options.SinceSeconds != nil {
+			return options, errors.New("only one of sinceSeconds or sinceTime can be provided")
+		}
+		sinceTime, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return options, fmt.Errorf("sinceTime %s is not a valid RFC3339 timestamp", v)
+		}
+		options.SinceTime = &metav1.Time{Time: sinceTime}
+	}
+	return options, nil
+}
+
+func (s *Server) logsAggregate(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	options := kubernetes.PodsLogAggregateOptions{}
+	var err error
+	if options.PodsLogOptions, err = podsLogOptions(ctr.GetArguments(), defaultLogsAggregateTail); err != nil {
+		return NewTextResult("", fmt.Errorf("failed to get aggregated logs, %s", err)), nil
+	}
+	namespace := ""
+	if v, ok := ctr.GetArguments()["namespace"].(string); ok {
+		namespace = v
+	}
+	if v, ok := ctr.GetArguments()["labelSelector"].(string); ok {
+		options.LabelSelector = v
+	}
+	if v, ok := ctr.GetArguments()["ownerKind"].(string); ok {
+		options.OwnerKind = v
+	}
+	if v, ok := ctr.GetArguments()["ownerName"].(string); ok {
+		options.OwnerName = v
+	}
+	if options.LabelSelector == "" && options.OwnerKind == "" {
+		return NewTextResult("", errors.New("failed to get aggregated logs, missing argument labelSelector or ownerKind")), nil
+	}
+	if options.LabelSelector != "" && options.OwnerKind != "" {
+		return NewTextResult("", errors.New("failed to get aggregated logs, only one of labelSelector or ownerKind can be provided")), nil
+	}
+	ret, err := s.k.Derived(ctx).PodsLogAggregate(ctx, namespace, options)
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to get aggregated logs: %v", err)), nil
+	} else if ret == "" {
+		ret = "The matching pods have not logged any message"
+	}
+	return NewTextResult(ret, nil), nil
+}
+
 func (s *Server) podsRun(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	ns := ctr.GetArguments()["namespace"]
 	if ns == nil {
@@ -286,7 +836,13 @@ func (s *Server) podsRun(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.Cal
 	if port == nil {
 		port = float64(0)
 	}
-	resources, err := s.k.Derived(ctx).PodsRun(ctx, ns.(string), name.(string), image.(string), int32(port.(float64)))
+	options, err := podsRunOptions(ctr.GetArguments())
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to run pod, %s", err)), nil
+	}
+	options.Image = image.(string)
+	options.Port = int32(port.(float64))
+	resources, err := s.k.Derived(ctx).PodsRun(ctx, ns.(string), name.(string), options)
 	if err != nil {
 		return NewTextResult("", fmt.Errorf("failed to run pod %s in namespace %s: %v", name, ns, err)), nil
 	}
@@ -296,3 +852,74 @@ func (s *Server) podsRun(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.Cal
 	}
 	return NewTextResult("# The following resources (YAML) have been created or updated successfully\n"+marshalledYaml, err), nil
 }
+
+// podsRunOptions returns the Pod spec options from the pods_run tool arguments
+func podsRunOptions(arguments map[string]interface{}) (kubernetes.PodsRunOptions, error) {
+	options := kubernetes.PodsRunOptions{
+		Command:      stringArrayArgument(arguments["command"]),
+		Args:         stringArrayArgument(arguments["args"]),
+		Env:          stringMapArgument(arguments["env"]),
+		NodeSelector: stringMapArgument(arguments["nodeSelector"]),
+	}
+	options.ServiceAccount, _ = arguments["serviceAccount"].(string)
+	if v, ok := arguments["restartPolicy"].(string); ok {
+		options.RestartPolicy = corev1.RestartPolicy(v)
+	}
+	var err error
+	if options.Resources.Requests, err = resourceListArgument(arguments["requests"]); err != nil {
+		return options, fmt.Errorf("invalid requests: %v", err)
+	}
+	if options.Resources.Limits, err = resourceListArgument(arguments["limits"]); err != nil {
+		return options, fmt.Errorf("invalid limits: %v", err)
+	}
+	if v, ok := arguments["ttl"].(float64); ok {
+		if v < 1 {
+			return options, errors.New("ttl must be a positive number")
+		}
+		options.TTL = time.Duration(v * float64(time.Second))
+	}
+	return options, nil
+}
+
+// stringArrayArgument returns the string items of an array tool argument, nil if not provided
+func stringArrayArgument(argument interface{}) []string {
+	var ret []string
+	if items, ok := argument.([]interface{}); ok {
+		for _, item := range items {
+			if v, ok := item.(string); ok {
+				ret = append(ret, v)
+			}
+		}
+	}
+	return ret
+}
+
+// stringMapArgument returns the values of an object tool argument as strings, nil if not provided
+func stringMapArgument(argument interface{}) map[string]string {
+	object, ok := argument.(map[string]interface{})
+	if !ok || len(object) == 0 {
+		return nil
+	}
+	ret := make(map[string]string, len(object))
+	for key, value := range object {
+		ret[key] = fmt.Sprint(value)
+	}
+	return ret
+}
+
+// resourceListArgument returns the resource quantities of an object tool argument (e.g. {"cpu": "100m"}), nil if not provided
+func resourceListArgument(argument interface{}) (corev1.ResourceList, error) {
+	quantities := stringMapArgument(argument)
+	if quantities == nil {
+		return nil, nil
+	}
+	ret := corev1.ResourceList{}
+	for name, value := range quantities {
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s %s is not a valid quantity", name, value)
+		}
+		ret[corev1.ResourceName(name)] = quantity
+	}
+	return ret, nil
+}
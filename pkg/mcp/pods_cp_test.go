@@ -0,0 +1,281 @@
+package mcp
+
+import (
+	"archive/tar"
+	"bytes"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/remotecommand"
+
+	"github.com/manusa/kubernetes-mcp-server/pkg/config"
+)
+
+// podsCpMockServer returns a mock server for the pod-to-copy Pod emulating the tar and ls commands on the provided files
+func podsCpMockServer(lock *sync.Mutex, files map[string][]byte) *MockServer {
+	mockServer := NewMockServer()
+	mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/api/v1/namespaces/default/pods/pod-to-copy" {
+			return
+		}
+		writeObject(w, &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pod-to-copy"},
+			Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "container-to-copy"}}},
+		})
+	}))
+	mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/api/v1/namespaces/default/pods/pod-to-copy/exec" {
+			return
+		}
+		var stdin, stdout, stderr bytes.Buffer
+		streamOptions := &StreamOptions{Stdout: &stdout, Stderr: &stderr}
+		if req.URL.Query().Get("stdin") == "true" {
+			streamOptions.Stdin = &stdin
+		}
+		ctx, err := createHTTPStreams(w, req, streamOptions)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(err.Error()))
+			return
+		}
+		defer func(conn io.Closer) { _ = conn.Close() }(ctx.conn)
+		lock.Lock()
+		defer lock.Unlock()
+		fail := func(message string) {
+			_, _ = io.WriteString(ctx.stderrStream, message)
+			_ = ctx.writeStatus(&apierrors.StatusError{ErrStatus: metav1.Status{
+				Status:  metav1.StatusFailure,
+				Reason:  remotecommand.NonZeroExitCodeReason,
+				Details: &metav1.StatusDetails{Causes: []metav1.StatusCause{{Type: remotecommand.ExitCodeCauseType, Message: "2"}}},
+			}})
+		}
+		command := req.URL.Query()["command"]
+		switch strings.Join(command[:2], " ") {
+		case "tar chf":
+			content, ok := files[command[4]]
+			if !ok {
+				fail("tar: " + command[4] + ": No such file or directory\n")
+				return
+			}
+			archive := tar.NewWriter(ctx.stdoutStream)
+			_ = archive.WriteHeader(&tar.Header{Typeflag: tar.TypeReg, Name: strings.TrimPrefix(command[4], "/"), Mode: 0600, Size: int64(len(content))})
+			_, _ = archive.Write(content)
+			_ = archive.Close()
+		case "tar xmf":
+			archive := tar.NewReader(ctx.stdinStream)
+			header, err := archive.Next()
+			if err != nil {
+				fail("tar: invalid archive\n")
+				return
+			}
+			files[path.Join(command[4], header.Name)], _ = io.ReadAll(archive)
+		case "ls -la":
+			if command[3] == "/mnt/stuck" {
+				time.Sleep(2 * time.Second)
+			}
+			_, _ = io.WriteString(ctx.stdoutStream, "total 0\n-rw------- 1 root root 11 Jan  1 00:00 config.yaml\n")
+		}
+	}))
+	return mockServer
+}
+
+func TestPodsCpFrom(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		lock := &sync.Mutex{}
+		mockServer := podsCpMockServer(lock, map[string][]byte{
+			"/etc/config.yaml": []byte("key: value\n"),
+			"/tmp/heap.bin":    {0xca, 0xfe, 0xba, 0xbe},
+		})
+		defer mockServer.Close()
+		c.withKubeConfig(mockServer.config)
+		t.Run("pods_cp_from with missing path returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("pods_cp_from", map[string]interface{}{"name": "pod-to-copy"})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "failed to copy from pod, missing argument path" {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		for _, filePath := range []string{"--checkpoint-action=exec=sh", "etc/config.yaml"} {
+			t.Run("pods_cp_from with relative path returns error "+filePath, func(t *testing.T) {
+				toolResult, _ := c.callTool("pods_cp_from", map[string]interface{}{"name": "pod-to-copy", "path": filePath})
+				if !toolResult.IsError {
+					t.Fatalf("call tool should fail")
+				}
+				if !strings.HasSuffix(toolResult.Content[0].(mcp.TextContent).Text, ": "+filePath+" is not an absolute path") {
+					t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+				}
+			})
+			t.Run("pods_cp_from with list and relative path returns error "+filePath, func(t *testing.T) {
+				toolResult, _ := c.callTool("pods_cp_from", map[string]interface{}{"name": "pod-to-copy", "path": filePath, "list": true})
+				if !toolResult.IsError {
+					t.Fatalf("call tool should fail")
+				}
+				if !strings.HasSuffix(toolResult.Content[0].(mcp.TextContent).Text, ": "+filePath+" is not an absolute path") {
+					t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+				}
+			})
+		}
+		t.Run("pods_cp_from with text file returns content", func(t *testing.T) {
+			toolResult, err := c.callTool("pods_cp_from", map[string]interface{}{"name": "pod-to-copy", "path": "/etc/config.yaml"})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			expected := "# Content of /etc/config.yaml (11 bytes, mode 0600)\nkey: value\n"
+			if toolResult.Content[0].(mcp.TextContent).Text != expected {
+				t.Fatalf("unexpected result %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("pods_cp_from with binary file returns base64 content", func(t *testing.T) {
+			toolResult, err := c.callTool("pods_cp_from", map[string]interface{}{"name": "pod-to-copy", "path": "/tmp/heap.bin"})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			expected := "# Content of /tmp/heap.bin (4 bytes, mode 0600, base64 encoded)\nyv66vg=="
+			if toolResult.Content[0].(mcp.TextContent).Text != expected {
+				t.Fatalf("unexpected result %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("pods_cp_from with file larger than maxBytes returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("pods_cp_from", map[string]interface{}{"name": "pod-to-copy", "path": "/etc/config.yaml", "maxBytes": 5})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			expected := "failed to copy /etc/config.yaml from pod pod-to-copy in namespace : /etc/config.yaml is 11 bytes, exceeds the limit of 5 bytes"
+			if toolResult.Content[0].(mcp.TextContent).Text != expected {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("pods_cp_from with not found file returns tar error", func(t *testing.T) {
+			toolResult, _ := c.callTool("pods_cp_from", map[string]interface{}{"name": "pod-to-copy", "path": "/not-found"})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			expected := "failed to copy /not-found from pod pod-to-copy in namespace : tar: /not-found: No such file or directory"
+			if toolResult.Content[0].(mcp.TextContent).Text != expected {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("pods_cp_from with list returns directory listing", func(t *testing.T) {
+			toolResult, err := c.callTool("pods_cp_from", map[string]interface{}{"name": "pod-to-copy", "path": "/etc", "list": true})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			if !strings.HasPrefix(toolResult.Content[0].(mcp.TextContent).Text, "# Content of the directory /etc\ntotal 0\n") {
+				t.Fatalf("unexpected result %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("pods_cp_from with list and timeout returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("pods_cp_from", map[string]interface{}{"name": "pod-to-copy", "path": "/mnt/stuck", "list": true, "timeout": 1})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			expected := "failed to list /mnt/stuck in pod pod-to-copy in namespace : /mnt/stuck: command didn't complete after 1s"
+			if toolResult.Content[0].(mcp.TextContent).Text != expected {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("pods_cp_from with invalid timeout returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("pods_cp_from", map[string]interface{}{"name": "pod-to-copy", "path": "/etc", "timeout": 0})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "failed to copy from pod, timeout must be a positive number" {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+	})
+}
+
+func TestPodsCpTo(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		lock := &sync.Mutex{}
+		files := map[string][]byte{}
+		mockServer := podsCpMockServer(lock, files)
+		defer mockServer.Close()
+		c.withKubeConfig(mockServer.config)
+		t.Run("pods_cp_to with text content copies the file", func(t *testing.T) {
+			toolResult, err := c.callTool("pods_cp_to", map[string]interface{}{
+				"name": "pod-to-copy", "path": "/tmp/script.sh", "content": "echo hello\n", "mode": "0755",
+			})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "11 bytes copied to /tmp/script.sh (mode 0755) in pod pod-to-copy" {
+				t.Fatalf("unexpected result %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+			lock.Lock()
+			defer lock.Unlock()
+			if string(files["/tmp/script.sh"]) != "echo hello\n" {
+				t.Fatalf("unexpected file content %v", files["/tmp/script.sh"])
+			}
+		})
+		t.Run("pods_cp_to with base64 content copies the decoded file", func(t *testing.T) {
+			toolResult, err := c.callTool("pods_cp_to", map[string]interface{}{
+				"name": "pod-to-copy", "path": "/tmp/data.bin", "content": base64.StdEncoding.EncodeToString([]byte{0xca, 0xfe}), "encoding": "base64",
+			})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			lock.Lock()
+			defer lock.Unlock()
+			if !bytes.Equal(files["/tmp/data.bin"], []byte{0xca, 0xfe}) {
+				t.Fatalf("unexpected file content %v", files["/tmp/data.bin"])
+			}
+		})
+		t.Run("pods_cp_to with invalid base64 content returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("pods_cp_to", map[string]interface{}{
+				"name": "pod-to-copy", "path": "/tmp/data.bin", "content": "not base64!", "encoding": "base64",
+			})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			if !strings.HasPrefix(toolResult.Content[0].(mcp.TextContent).Text, "failed to copy to pod, content is not valid base64") {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("pods_cp_to with content larger than maxBytes returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("pods_cp_to", map[string]interface{}{
+				"name": "pod-to-copy", "path": "/tmp/script.sh", "content": "echo hello\n", "maxBytes": 5,
+			})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "failed to copy to pod, content is 11 bytes, exceeds the limit of 5 bytes" {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+	})
+}
+
+func TestPodsCpDenied(t *testing.T) {
+	deniedResourcesServer := &config.StaticConfig{DeniedResources: []config.GroupVersionKind{{Version: "v1", Kind: "Pod"}}}
+	testCaseWithContext(t, &mcpContext{staticConfig: deniedResourcesServer}, func(c *mcpContext) {
+		c.withEnvTest()
+		podsCpFrom, _ := c.callTool("pods_cp_from", map[string]interface{}{"namespace": "default", "name": "a-pod-in-default", "path": "/etc/hosts"})
+		t.Run("pods_cp_from describes denial", func(t *testing.T) {
+			expectedMessage := "failed to copy /etc/hosts from pod a-pod-in-default in namespace default: resource not allowed: /v1, Kind=Pod"
+			if !podsCpFrom.IsError || podsCpFrom.Content[0].(mcp.TextContent).Text != expectedMessage {
+				t.Fatalf("expected descriptive error '%s', got %v", expectedMessage, podsCpFrom.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		podsCpTo, _ := c.callTool("pods_cp_to", map[string]interface{}{"namespace": "default", "name": "a-pod-in-default", "path": "/tmp/file", "content": "content"})
+		t.Run("pods_cp_to describes denial", func(t *testing.T) {
+			expectedMessage := "failed to copy /tmp/file to pod a-pod-in-default in namespace default: resource not allowed: /v1, Kind=Pod"
+			if !podsCpTo.IsError || podsCpTo.Content[0].(mcp.TextContent).Text != expectedMessage {
+				t.Fatalf("expected descriptive error '%s', got %v", expectedMessage, podsCpTo.Content[0].(mcp.TextContent).Text)
+			}
+		})
+	})
+}
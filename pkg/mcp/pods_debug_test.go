@@ -0,0 +1,198 @@
+package mcp
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+
+	"github.com/manusa/kubernetes-mcp-server/pkg/config"
+	"github.com/manusa/kubernetes-mcp-server/pkg/kubernetes"
+)
+
+func TestPodsDebug(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		mockServer := NewMockServer()
+		defer mockServer.Close()
+		c.withKubeConfig(mockServer.config)
+		var lock sync.Mutex
+		pod := &v1.Pod{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "distroless-pod"},
+			Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "app"}}},
+			Status:     v1.PodStatus{Phase: v1.PodRunning},
+		}
+		mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			lock.Lock()
+			defer lock.Unlock()
+			switch req.URL.Path {
+			case "/api/v1/namespaces/default/pods/distroless-pod":
+				writeObject(w, pod)
+			case "/api/v1/namespaces/default/pods/distroless-pod/ephemeralcontainers":
+				// The request body might be encoded as protobuf (client-go default content type for built-in types)
+				body, _ := io.ReadAll(req.Body)
+				obj, _, err := scheme.Codecs.UniversalDeserializer().Decode(body, nil, nil)
+				if err != nil {
+					http.Error(w, err.Error(), http.StatusBadRequest)
+					return
+				}
+				updated := obj.(*v1.Pod)
+				pod.Spec.EphemeralContainers = updated.Spec.EphemeralContainers
+				pod.Status.EphemeralContainerStatuses = nil
+				for _, ec := range updated.Spec.EphemeralContainers {
+					pod.Status.EphemeralContainerStatuses = append(pod.Status.EphemeralContainerStatuses, v1.ContainerStatus{
+						Name: ec.Name, State: v1.ContainerState{Running: &v1.ContainerStateRunning{}},
+					})
+				}
+				writeObject(w, pod)
+			case "/api/v1/namespaces/default/pods/distroless-pod/exec":
+				var stdout, stderr bytes.Buffer
+				ctx, err := createHTTPStreams(w, req, &StreamOptions{Stdout: &stdout, Stderr: &stderr})
+				if err != nil {
+					w.WriteHeader(http.StatusInternalServerError)
+					_, _ = w.Write([]byte(err.Error()))
+					return
+				}
+				defer func(conn io.Closer) { _ = conn.Close() }(ctx.conn)
+				_, _ = io.WriteString(ctx.stdoutStream, "command:"+strings.Join(req.URL.Query()["command"], " ")+"\n")
+				_, _ = io.WriteString(ctx.stdoutStream, "container:"+strings.Join(req.URL.Query()["container"], " ")+"\n")
+			}
+		}))
+		t.Run("pods_debug with missing name and node returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("pods_debug", map[string]interface{}{})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "failed to debug, missing argument name or node" {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("pods_debug with name and node returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("pods_debug", map[string]interface{}{"name": "distroless-pod", "node": "a-node"})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "failed to debug, only one of name or node can be provided" {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("pods_debug with not found target returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("pods_debug", map[string]interface{}{"namespace": "default", "name": "distroless-pod", "target": "not-found"})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "failed to debug pod distroless-pod in namespace default: target container not-found not found in pod distroless-pod" {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		toolResult, err := c.callTool("pods_debug", map[string]interface{}{
+			"namespace": "default", "name": "distroless-pod", "target": "app", "command": []interface{}{"ps", "aux"},
+		})
+		t.Run("pods_debug adds an ephemeral container", func(t *testing.T) {
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			lock.Lock()
+			defer lock.Unlock()
+			if len(pod.Spec.EphemeralContainers) != 1 {
+				t.Fatalf("expected 1 ephemeral container, got %v", len(pod.Spec.EphemeralContainers))
+			}
+			ec := pod.Spec.EphemeralContainers[0]
+			if !strings.HasPrefix(ec.Name, "debugger-") {
+				t.Errorf("unexpected ephemeral container name %s", ec.Name)
+			}
+			if ec.Image != "busybox" {
+				t.Errorf("expected default busybox image, got %s", ec.Image)
+			}
+			if ec.TargetContainerName != "app" {
+				t.Errorf("expected target container app, got %s", ec.TargetContainerName)
+			}
+			if !strings.HasPrefix(toolResult.Content[0].(mcp.TextContent).Text, "# Ephemeral debug container "+ec.Name+" added to pod distroless-pod in namespace default\n") {
+				t.Errorf("unexpected result %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("pods_debug executes the command in the ephemeral container", func(t *testing.T) {
+			text := toolResult.Content[0].(mcp.TextContent).Text
+			if !strings.Contains(text, "command:ps aux\n") {
+				t.Errorf("expected command output, got %v", text)
+			}
+			if !strings.Contains(text, "container:"+pod.Spec.EphemeralContainers[0].Name+"\n") {
+				t.Errorf("expected command to be executed in the ephemeral container, got %v", text)
+			}
+		})
+	})
+}
+
+func TestPodsDebugNode(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		c.withEnvTest()
+		kc := c.newKubernetesClient()
+		_, _ = kc.CoreV1().Nodes().Create(c.ctx, &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "a-node-to-debug"}}, metav1.CreateOptions{})
+		t.Run("pods_debug with not found node returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("pods_debug", map[string]interface{}{"node": "not-found"})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "failed to debug node not-found: nodes \"not-found\" not found" {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		toolResult, _ := c.callTool("pods_debug", map[string]interface{}{"node": "a-node-to-debug", "timeout": 1})
+		t.Run("pods_debug with node reports the debug pod is not running (no kubelet)", func(t *testing.T) {
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			if !strings.Contains(toolResult.Content[0].(mcp.TextContent).Text, "failed to debug: container debugger is not running after 1s") {
+				t.Fatalf("unexpected result %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("pods_debug with node creates a privileged pod labeled as a sandbox resource", func(t *testing.T) {
+			pods, err := kc.CoreV1().Pods("default").List(c.ctx, metav1.ListOptions{
+				LabelSelector: "app.kubernetes.io/part-of=kubernetes-mcp-server-run-sandbox",
+				FieldSelector: "spec.nodeName=a-node-to-debug",
+			})
+			if err != nil || len(pods.Items) != 1 {
+				t.Fatalf("expected 1 node debug pod, got %v %v", pods, err)
+			}
+			pod := pods.Items[0]
+			if !pod.Spec.HostPID || !pod.Spec.HostNetwork || !pod.Spec.HostIPC {
+				t.Errorf("expected the debug pod to share the host namespaces")
+			}
+			if pod.Spec.Containers[0].SecurityContext == nil || !*pod.Spec.Containers[0].SecurityContext.Privileged {
+				t.Errorf("expected the debug container to be privileged")
+			}
+			if pod.Spec.Volumes[0].HostPath == nil || pod.Spec.Volumes[0].HostPath.Path != "/" {
+				t.Errorf("expected the host filesystem to be mounted")
+			}
+			if _, ok := pod.Annotations[kubernetes.RunSandboxExpiresAtAnnotation]; !ok {
+				t.Errorf("expected the debug pod to expire, got annotations %v", pod.Annotations)
+			}
+		})
+	})
+}
+
+func TestPodsDebugDenied(t *testing.T) {
+	deniedResourcesServer := &config.StaticConfig{DeniedResources: []config.GroupVersionKind{{Version: "v1", Kind: "Pod"}}}
+	testCaseWithContext(t, &mcpContext{staticConfig: deniedResourcesServer}, func(c *mcpContext) {
+		c.withEnvTest()
+		toolResult, _ := c.callTool("pods_debug", map[string]interface{}{"namespace": "default", "name": "a-pod-in-default"})
+		t.Run("pods_debug has error", func(t *testing.T) {
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+		})
+		t.Run("pods_debug describes denial", func(t *testing.T) {
+			expectedMessage := "failed to debug pod a-pod-in-default in namespace default: resource not allowed: /v1, Kind=Pod"
+			if toolResult.Content[0].(mcp.TextContent).Text != expectedMessage {
+				t.Fatalf("expected descriptive error '%s', got %v", expectedMessage, toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+	})
+}
@@ -6,10 +6,13 @@ import (
 	"github.com/mark3labs/mcp-go/mcp"
 	"io"
 	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/remotecommand"
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestPodsExec(t *testing.T) {
@@ -101,6 +104,120 @@ func TestPodsExec(t *testing.T) {
 	})
 }
 
+func TestPodsExecOptions(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		mockServer := NewMockServer()
+		defer mockServer.Close()
+		c.withKubeConfig(mockServer.config)
+		mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if req.URL.Path != "/api/v1/namespaces/default/pods/pod-to-exec/exec" {
+				return
+			}
+			var stdin, stdout, stderr bytes.Buffer
+			streamOptions := &StreamOptions{Stdout: &stdout, Stderr: &stderr}
+			if req.URL.Query().Get("stdin") == "true" {
+				streamOptions.Stdin = &stdin
+			}
+			ctx, err := createHTTPStreams(w, req, streamOptions)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				_, _ = w.Write([]byte(err.Error()))
+				return
+			}
+			defer func(conn io.Closer) { _ = conn.Close() }(ctx.conn)
+			switch req.URL.Query()["command"][0] {
+			case "cat":
+				in, _ := io.ReadAll(ctx.stdinStream)
+				_, _ = ctx.stdoutStream.Write(in)
+			case "fail":
+				_, _ = io.WriteString(ctx.stdoutStream, "some output\n")
+				_, _ = io.WriteString(ctx.stderrStream, "something went wrong\n")
+				_ = ctx.writeStatus(&apierrors.StatusError{ErrStatus: metav1.Status{
+					Status: metav1.StatusFailure,
+					Reason: remotecommand.NonZeroExitCodeReason,
+					Details: &metav1.StatusDetails{
+						Causes: []metav1.StatusCause{{Type: remotecommand.ExitCodeCauseType, Message: "3"}},
+					},
+				}})
+			case "verbose":
+				_, _ = io.WriteString(ctx.stdoutStream, strings.Repeat("a", 64))
+			case "sleep":
+				_, _ = io.WriteString(ctx.stdoutStream, "started\n")
+				time.Sleep(2 * time.Second)
+			}
+			_ = ctx.stdoutStream.Close()
+			_ = ctx.stderrStream.Close()
+		}))
+		mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if req.URL.Path != "/api/v1/namespaces/default/pods/pod-to-exec" {
+				return
+			}
+			writeObject(w, &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pod-to-exec"},
+				Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "container-to-exec"}}},
+			})
+		}))
+		t.Run("pods_exec with stdin sends the content to the command", func(t *testing.T) {
+			toolResult, err := c.callTool("pods_exec", map[string]interface{}{
+				"namespace": "default", "name": "pod-to-exec", "command": []interface{}{"cat"}, "stdin": "hello from stdin\n",
+			})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			expected := "# The command exited with code 0\n# stdout\nhello from stdin\n"
+			if toolResult.Content[0].(mcp.TextContent).Text != expected {
+				t.Fatalf("unexpected result %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("pods_exec with failing command returns exit code, stdout and stderr", func(t *testing.T) {
+			toolResult, err := c.callTool("pods_exec", map[string]interface{}{
+				"namespace": "default", "name": "pod-to-exec", "command": []interface{}{"fail"},
+			})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			expected := "# The command exited with code 3\n# stdout\nsome output\n# stderr\nsomething went wrong\n"
+			if toolResult.Content[0].(mcp.TextContent).Text != expected {
+				t.Fatalf("unexpected result %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("pods_exec with maxOutputBytes truncates the output", func(t *testing.T) {
+			toolResult, err := c.callTool("pods_exec", map[string]interface{}{
+				"namespace": "default", "name": "pod-to-exec", "command": []interface{}{"verbose"}, "maxOutputBytes": 10,
+			})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			expected := "# The command exited with code 0\n# stdout\naaaaaaaaaa\n[... stdout truncated, exceeded 10 bytes]\n"
+			if toolResult.Content[0].(mcp.TextContent).Text != expected {
+				t.Fatalf("unexpected result %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("pods_exec with timeout returns the partial output", func(t *testing.T) {
+			toolResult, err := c.callTool("pods_exec", map[string]interface{}{
+				"namespace": "default", "name": "pod-to-exec", "command": []interface{}{"sleep"}, "timeout": 1,
+			})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			if !strings.HasPrefix(toolResult.Content[0].(mcp.TextContent).Text, "# The command didn't complete in 1s, the output captured until then follows\n") {
+				t.Fatalf("unexpected result %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("pods_exec with invalid timeout returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("pods_exec", map[string]interface{}{
+				"namespace": "default", "name": "pod-to-exec", "command": []interface{}{"ls"}, "timeout": 0,
+			})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "failed to exec in pod, timeout must be a positive number" {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+	})
+}
+
 func TestPodsExecDenied(t *testing.T) {
 	deniedResourcesServer := &config.StaticConfig{DeniedResources: []config.GroupVersionKind{{Version: "v1", Kind: "Pod"}}}
 	testCaseWithContext(t, &mcpContext{staticConfig: deniedResourcesServer}, func(c *mcpContext) {
@@ -3,9 +3,12 @@ package mcp
 import (
 	"github.com/manusa/kubernetes-mcp-server/pkg/config"
 	"github.com/manusa/kubernetes-mcp-server/pkg/output"
+	"net/http"
 	"regexp"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	corev1 "k8s.io/api/core/v1"
@@ -721,6 +724,214 @@ func TestPodsLog(t *testing.T) {
 	})
 }
 
+func TestPodsLogOptions(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		c.withEnvTest()
+		_, _ = c.newKubernetesClient().CoreV1().Pods("default").Create(c.ctx, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "a-multi-container-pod"},
+			Spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{{Name: "init", Image: "busybox"}},
+				Containers:     []corev1.Container{{Name: "nginx", Image: "nginx"}, {Name: "sidecar", Image: "busybox"}},
+			},
+		}, metav1.CreateOptions{})
+		t.Run("pods_log in multi-container pod without container returns error listing containers", func(t *testing.T) {
+			toolResult, _ := c.callTool("pods_log", map[string]interface{}{"name": "a-multi-container-pod"})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			expectedMessage := "failed to get pod a-multi-container-pod log in namespace : pod a-multi-container-pod has 2 containers, " +
+				"a container name must be specified, choose one of: nginx, sidecar (or one of the init containers: init)"
+			if toolResult.Content[0].(mcp.TextContent).Text != expectedMessage {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("pods_log in multi-container pod with container returns pod log", func(t *testing.T) {
+			toolResult, err := c.callTool("pods_log", map[string]interface{}{"name": "a-multi-container-pod", "container": "sidecar"})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+		})
+		t.Run("pods_log with invalid sinceTime returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("pods_log", map[string]interface{}{"name": "a-pod-in-default", "sinceTime": "yesterday"})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "failed to get pod log, sinceTime yesterday is not a valid RFC3339 timestamp" {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("pods_log with sinceSeconds and sinceTime returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("pods_log", map[string]interface{}{"name": "a-pod-in-default", "sinceSeconds": 60, "sinceTime": "2025-01-01T10:00:00Z"})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "failed to get pod log, only one of sinceSeconds or sinceTime can be provided" {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("pods_log with invalid grep returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("pods_log", map[string]interface{}{"name": "a-pod-in-default", "grep": "error("})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			if !strings.HasPrefix(toolResult.Content[0].(mcp.TextContent).Text, "failed to get pod a-pod-in-default log in namespace : invalid grep regular expression error(") {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("pods_log with all options returns pod log", func(t *testing.T) {
+			toolResult, err := c.callTool("pods_log", map[string]interface{}{
+				"name": "a-pod-in-default", "sinceSeconds": 60, "tail": -1, "timestamps": true, "limitBytes": 1024, "grep": "(?i)error",
+			})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "The pod a-pod-in-default in namespace  has not logged any message matching (?i)error" {
+				t.Fatalf("unexpected result %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+	})
+}
+
+func TestPodsLogFollow(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		c.withEnvTest()
+		t.Run("pods_log with follow and invalid followSeconds returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("pods_log", map[string]interface{}{"name": "a-pod-in-default", "follow": true, "followSeconds": 3600})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "failed to follow pod log, followSeconds must be between 1 and 300" {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("pods_log with follow and invalid followLines returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("pods_log", map[string]interface{}{"name": "a-pod-in-default", "follow": true, "followLines": 0})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "failed to follow pod log, followLines must be a positive number" {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("pods_log with follow and not found name returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("pods_log", map[string]interface{}{"name": "not-found", "follow": true, "followSeconds": 1})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "failed to follow pod not-found log in namespace : pods \"not-found\" not found" {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("pods_log with follow stops following and reports it", func(t *testing.T) {
+			toolResult, err := c.callTool("pods_log", map[string]interface{}{"name": "a-pod-in-default", "follow": true, "followSeconds": 1})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			if !strings.Contains(toolResult.Content[0].(mcp.TextContent).Text, "# Stopped following the pod a-pod-in-default log in namespace  after ") {
+				t.Fatalf("unexpected result, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+	})
+}
+
+func TestLogsAggregate(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		c.withEnvTest()
+		t.Run("logs_aggregate with missing selector and owner returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("logs_aggregate", map[string]interface{}{})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "failed to get aggregated logs, missing argument labelSelector or ownerKind" {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("logs_aggregate with unsupported owner kind returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("logs_aggregate", map[string]interface{}{"ownerKind": "CronJob", "ownerName": "a-cronjob"})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "failed to get aggregated logs: owner kind CronJob is not supported" {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("logs_aggregate with no matching pods returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("logs_aggregate", map[string]interface{}{"labelSelector": "app=not-found"})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "failed to get aggregated logs: no pods found in namespace default matching the label selector app=not-found" {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("logs_aggregate with label selector returns logs", func(t *testing.T) {
+			toolResult, err := c.callTool("logs_aggregate", map[string]interface{}{"labelSelector": "app=nginx"})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "The matching pods have not logged any message" {
+				t.Fatalf("unexpected result %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		_, _ = c.newKubernetesClient().AppsV1().Deployments("default").Create(c.ctx, rolloutDeployment("nginx", "nginx"), metav1.CreateOptions{})
+		t.Run("logs_aggregate with owner returns logs", func(t *testing.T) {
+			toolResult, err := c.callTool("logs_aggregate", map[string]interface{}{"ownerKind": "Deployment", "ownerName": "nginx", "tail": 10})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "The matching pods have not logged any message" {
+				t.Fatalf("unexpected result %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("logs_aggregate with not found owner returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("logs_aggregate", map[string]interface{}{"ownerKind": "Job", "ownerName": "a-job"})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "failed to get aggregated logs: jobs.batch \"a-job\" not found" {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+	})
+}
+
+func TestLogsAggregateGrep(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		mockServer := NewMockServer()
+		defer mockServer.Close()
+		c.withKubeConfig(mockServer.config)
+		mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch req.URL.Path {
+			case "/api":
+				_, _ = w.Write([]byte(`{"kind":"APIVersions","versions":["v1"],"serverAddressByClientCIDRs":[{"clientCIDR":"0.0.0.0/0"}]}`))
+			case "/apis":
+				_, _ = w.Write([]byte(`{"kind":"APIGroupList","apiVersion":"v1","groups":[]}`))
+			case "/api/v1":
+				_, _ = w.Write([]byte(`{"kind":"APIResourceList","apiVersion":"v1","resources":[{"name":"pods","singularName":"","namespaced":true,"kind":"Pod","verbs":["get","list"]}]}`))
+			case "/api/v1/namespaces/default/pods":
+				_, _ = w.Write([]byte(`{"kind":"PodList","apiVersion":"v1","items":[` +
+					`{"metadata":{"name":"web-1","namespace":"default","labels":{"app":"web"}},"spec":{"containers":[{"name":"web"}]}}]}`))
+			case "/api/v1/namespaces/default/pods/web-1/log":
+				w.Header().Set("Content-Type", "text/plain")
+				_, _ = w.Write([]byte("2025-01-01T00:00:01Z ERROR first failure\n" +
+					"2025-01-01T00:00:02Z INFO request without ERROR prefix\n" +
+					"2025-01-01T00:00:03Z ERROR second failure\n"))
+			}
+		}))
+		toolResult, err := c.callTool("logs_aggregate", map[string]interface{}{"labelSelector": "app=web", "grep": "^ERROR"})
+		t.Run("logs_aggregate with anchored grep matches the messages without timestamps", func(t *testing.T) {
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			expected := "[web-1/web] ERROR first failure\n[web-1/web] ERROR second failure\n"
+			if toolResult.Content[0].(mcp.TextContent).Text != expected {
+				t.Fatalf("unexpected result %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+	})
+}
+
 func TestPodsLogDenied(t *testing.T) {
 	deniedResourcesServer := &config.StaticConfig{DeniedResources: []config.GroupVersionKind{{Version: "v1", Kind: "Pod"}}}
 	testCaseWithContext(t, &mcpContext{staticConfig: deniedResourcesServer}, func(c *mcpContext) {
@@ -813,6 +1024,10 @@ func TestPodsRun(t *testing.T) {
 				t.Errorf("invalid labels, expected app.kubernetes.io/part-of, got %v", labels)
 				return
 			}
+			if labels["kubernetes-mcp-server/instance"] != c.mcpServer.k.RunSandboxInstance() {
+				t.Errorf("invalid labels, expected kubernetes-mcp-server/instance, got %v", labels)
+				return
+			}
 		})
 		t.Run("pods_run with image and nil namespace returns pod with nginx container", func(t *testing.T) {
 			containers := decodedNilNamespace[0].Object["spec"].(map[string]interface{})["containers"].([]interface{})
@@ -890,6 +1105,152 @@ func TestPodsRun(t *testing.T) {
 	})
 }
 
+func TestPodsRunWithOptions(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		c.withEnvTest()
+		t.Run("pods_run with invalid requests returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("pods_run", map[string]interface{}{
+				"image":    "busybox",
+				"requests": map[string]interface{}{"cpu": "a lot"},
+			})
+			if toolResult.IsError != true {
+				t.Fatalf("call tool should fail")
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "failed to run pod, invalid requests: cpu a lot is not a valid quantity" {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		podsRun, err := c.callTool("pods_run", map[string]interface{}{
+			"name":          "a-pod-with-options",
+			"image":         "busybox",
+			"command":       []interface{}{"sh", "-c"},
+			"args":          []interface{}{"echo $GREETING"},
+			"env":           map[string]interface{}{"GREETING": "hello", "ANSWER": 42},
+			"requests":      map[string]interface{}{"cpu": "100m", "memory": "64Mi"},
+			"limits":        map[string]interface{}{"memory": "128Mi"},
+			"restartPolicy": "Never",
+			"nodeSelector":  map[string]interface{}{"kubernetes.io/os": "linux"},
+			"ttl":           600,
+		})
+		t.Run("pods_run with options runs pod", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("call tool failed %v", err)
+			}
+			if podsRun.IsError {
+				t.Fatalf("call tool failed %v", podsRun.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		pod, err := c.newKubernetesClient().CoreV1().Pods("default").Get(c.ctx, "a-pod-with-options", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("failed to get pod %v", err)
+		}
+		container := pod.Spec.Containers[0]
+		t.Run("pods_run with options sets command and args", func(t *testing.T) {
+			if strings.Join(container.Command, " ") != "sh -c" {
+				t.Errorf("invalid command, got %v", container.Command)
+			}
+			if strings.Join(container.Args, " ") != "echo $GREETING" {
+				t.Errorf("invalid args, got %v", container.Args)
+			}
+		})
+		t.Run("pods_run with options sets sorted env", func(t *testing.T) {
+			if len(container.Env) != 2 || container.Env[0].Name != "ANSWER" || container.Env[0].Value != "42" ||
+				container.Env[1].Name != "GREETING" || container.Env[1].Value != "hello" {
+				t.Errorf("invalid env, got %v", container.Env)
+			}
+		})
+		t.Run("pods_run with options sets resources", func(t *testing.T) {
+			if container.Resources.Requests.Cpu().String() != "100m" || container.Resources.Requests.Memory().String() != "64Mi" {
+				t.Errorf("invalid requests, got %v", container.Resources.Requests)
+			}
+			if container.Resources.Limits.Memory().String() != "128Mi" {
+				t.Errorf("invalid limits, got %v", container.Resources.Limits)
+			}
+		})
+		t.Run("pods_run with options sets restart policy and node selector", func(t *testing.T) {
+			if pod.Spec.RestartPolicy != corev1.RestartPolicyNever {
+				t.Errorf("invalid restart policy, got %v", pod.Spec.RestartPolicy)
+			}
+			if pod.Spec.NodeSelector["kubernetes.io/os"] != "linux" {
+				t.Errorf("invalid node selector, got %v", pod.Spec.NodeSelector)
+			}
+		})
+		t.Run("pods_run with ttl sets expiration annotation", func(t *testing.T) {
+			expiresAt, err := time.Parse(time.RFC3339, pod.Annotations["kubernetes-mcp-server/expires-at"])
+			if err != nil {
+				t.Fatalf("invalid expires-at annotation, got %v", pod.Annotations)
+			}
+			if expiresAt.Before(time.Now().Add(9*time.Minute)) || expiresAt.After(time.Now().Add(11*time.Minute)) {
+				t.Errorf("invalid expires-at annotation, expected ~10 minutes from now, got %v", expiresAt)
+			}
+		})
+	})
+}
+
+func TestPodsRunSandboxReap(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		c.withEnvTest()
+		kc := c.newKubernetesClient()
+		sandbox := func(name, expiresAt, instance string) {
+			labels := map[string]string{
+				"app.kubernetes.io/name":         name,
+				"app.kubernetes.io/managed-by":   "kubernetes-mcp-server",
+				"app.kubernetes.io/part-of":      "kubernetes-mcp-server-run-sandbox",
+				"kubernetes-mcp-server/instance": instance,
+			}
+			annotations := map[string]string{"kubernetes-mcp-server/expires-at": expiresAt}
+			_, _ = kc.CoreV1().Pods("default").Create(c.ctx, &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels, Annotations: annotations},
+				Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "nginx", Image: "nginx"}}},
+			}, metav1.CreateOptions{})
+			_, _ = kc.CoreV1().Services("default").Create(c.ctx, &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels, Annotations: annotations},
+				Spec:       corev1.ServiceSpec{Selector: labels, Ports: []corev1.ServicePort{{Port: 80}}},
+			}, metav1.CreateOptions{})
+		}
+		sandbox("an-expired-sandbox", time.Now().Add(-time.Minute).UTC().Format(time.RFC3339), c.mcpServer.k.RunSandboxInstance())
+		sandbox("a-live-sandbox", time.Now().Add(time.Hour).UTC().Format(time.RFC3339), c.mcpServer.k.RunSandboxInstance())
+		sandbox("another-instance-sandbox", time.Now().Add(-time.Minute).UTC().Format(time.RFC3339), "another-instance")
+		reaped, err := c.mcpServer.k.Derived(c.ctx).RunSandboxReap(c.ctx)
+		t.Run("RunSandboxReap returns no error", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("reap failed %v", err)
+			}
+		})
+		t.Run("RunSandboxReap reports the expired Pod", func(t *testing.T) {
+			if !slices.Contains(reaped, "pod/default/an-expired-sandbox") {
+				t.Errorf("expired pod not reported, got %v", reaped)
+			}
+		})
+		t.Run("RunSandboxReap deletes the expired Pod and its Service", func(t *testing.T) {
+			p, pErr := kc.CoreV1().Pods("default").Get(c.ctx, "an-expired-sandbox", metav1.GetOptions{})
+			if pErr == nil && p != nil && p.ObjectMeta.DeletionTimestamp == nil {
+				t.Errorf("Pod not deleted")
+			}
+			s, sErr := kc.CoreV1().Services("default").Get(c.ctx, "an-expired-sandbox", metav1.GetOptions{})
+			if sErr == nil && s != nil && s.ObjectMeta.DeletionTimestamp == nil {
+				t.Errorf("Service not deleted")
+			}
+		})
+		t.Run("RunSandboxReap keeps the expired Pod of another instance", func(t *testing.T) {
+			p, pErr := kc.CoreV1().Pods("default").Get(c.ctx, "another-instance-sandbox", metav1.GetOptions{})
+			if pErr != nil || p.ObjectMeta.DeletionTimestamp != nil {
+				t.Errorf("Pod deleted %v", pErr)
+			}
+		})
+		t.Run("RunSandboxReap keeps the live Pod and its Service", func(t *testing.T) {
+			p, pErr := kc.CoreV1().Pods("default").Get(c.ctx, "a-live-sandbox", metav1.GetOptions{})
+			if pErr != nil || p.ObjectMeta.DeletionTimestamp != nil {
+				t.Errorf("Pod deleted %v", pErr)
+			}
+			s, sErr := kc.CoreV1().Services("default").Get(c.ctx, "a-live-sandbox", metav1.GetOptions{})
+			if sErr != nil || s.ObjectMeta.DeletionTimestamp != nil {
+				t.Errorf("Service deleted %v", sErr)
+			}
+		})
+	})
+}
+
 func TestPodsRunDenied(t *testing.T) {
 	deniedResourcesServer := &config.StaticConfig{DeniedResources: []config.GroupVersionKind{{Version: "v1", Kind: "Pod"}}}
 	testCaseWithContext(t, &mcpContext{staticConfig: deniedResourcesServer}, func(c *mcpContext) {
@@ -1049,3 +1410,75 @@ func TestPodsListWithLabelSelector(t *testing.T) {
 		})
 	})
 }
+
+func TestPodsListWithFieldSelector(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		c.withEnvTest()
+		t.Run("pods_list with field selector returns filtered pods", func(t *testing.T) {
+			toolResult, err := c.callTool("pods_list", map[string]interface{}{
+				"fieldSelector": "metadata.name=a-pod-in-ns-1",
+			})
+			if err != nil {
+				t.Fatalf("call tool failed %v", err)
+				return
+			}
+			if toolResult.IsError {
+				t.Fatalf("call tool failed")
+				return
+			}
+			var decoded []unstructured.Unstructured
+			err = yaml.Unmarshal([]byte(toolResult.Content[0].(mcp.TextContent).Text), &decoded)
+			if err != nil {
+				t.Fatalf("invalid tool result content %v", err)
+				return
+			}
+			if len(decoded) != 1 {
+				t.Fatalf("invalid pods count, expected 1, got %v", len(decoded))
+				return
+			}
+			if decoded[0].GetName() != "a-pod-in-ns-1" {
+				t.Fatalf("invalid pod name, expected a-pod-in-ns-1, got %v", decoded[0].GetName())
+				return
+			}
+		})
+		t.Run("pods_list_in_namespace with pod specific field selector returns filtered pods", func(t *testing.T) {
+			toolResult, err := c.callTool("pods_list_in_namespace", map[string]interface{}{
+				"namespace":     "ns-1",
+				"fieldSelector": "spec.nodeName=worker-1",
+			})
+			if err != nil {
+				t.Fatalf("call tool failed %v", err)
+				return
+			}
+			if toolResult.IsError {
+				t.Fatalf("call tool failed")
+				return
+			}
+			var decoded []unstructured.Unstructured
+			err = yaml.Unmarshal([]byte(toolResult.Content[0].(mcp.TextContent).Text), &decoded)
+			if err != nil {
+				t.Fatalf("invalid tool result content %v", err)
+				return
+			}
+			if len(decoded) != 0 {
+				t.Fatalf("invalid pods count, expected 0, got %v", len(decoded))
+				return
+			}
+		})
+		t.Run("pods_list with unsupported field selector returns clear error", func(t *testing.T) {
+			toolResult, _ := c.callTool("pods_list", map[string]interface{}{
+				"fieldSelector": "spec.foo=bar",
+			})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+				return
+			}
+			expectedMessage := "failed to list pods in all namespaces: field selector spec.foo=bar is not supported for Pod, the field spec.foo can't be used to filter " +
+				"(only metadata.name, metadata.namespace, and a few kind-specific fields such as status.phase or spec.nodeName for Pods are supported)"
+			if toolResult.Content[0].(mcp.TextContent).Text != expectedMessage {
+				t.Fatalf("invalid error message, expected %s, got %s", expectedMessage, toolResult.Content[0].(mcp.TextContent).Text)
+				return
+			}
+		})
+	})
+}
@@ -0,0 +1,140 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/manusa/kubernetes-mcp-server/pkg/kubernetes"
+)
+
+const (
+	defaultHTTPProbeTimeout      = 10
+	defaultHTTPProbeMaxBodyBytes = 16 * 1024
+)
+
+var httpProbeMethods = []string{http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete, http.MethodOptions}
+
+func (s *Server) initProbe() []server.ServerTool {
+	return []server.ServerTool{
+		{Tool: mcp.NewTool("http_probe",
+			mcp.WithDescription("Perform an HTTP request to a Kubernetes Pod or Service port in the current or provided namespace and return the response status, headers, and (truncated) body. "+
+				"The request goes through the API server proxy or through a temporary port-forward, no HTTP client (e.g. curl) is required in the container image. "+
+				"Useful to verify that a workload actually responds"),
+			mcp.WithString("kind", mcp.Description("Kind of the target"), mcp.Enum("Pod", "Service"), mcp.Required()),
+			mcp.WithString("name", mcp.Description("Name of the Pod or Service"), mcp.Required()),
+			mcp.WithString("namespace", mcp.Description("Namespace of the Pod or Service (Optional, current namespace if not provided)")),
+			mcp.WithString("port", mcp.Description("Number or name of the Pod container port or Service port (e.g. 8080 or http)"), mcp.Required()),
+			mcp.WithString("path", mcp.Description("Path and query of the request (Optional, / if not provided), e.g. /healthz?verbose=true")),
+			mcp.WithString("method", mcp.Description("HTTP method of the request (Optional, GET if not provided)"), mcp.Enum(httpProbeMethods...)),
+			mcp.WithObject("headers", mcp.Description("HTTP headers of the request (Optional), e.g. {\"Accept\": \"application/json\"}. "+
+				"Hop-by-hop headers are not sent, neither are Authorization, Cookie, and Impersonate-* headers in proxy mode")),
+			mcp.WithString("body", mcp.Description("Body of the request (Optional)")),
+			mcp.WithString("scheme", mcp.Description("Scheme of the request (Optional, http if not provided), certificates are not verified for https requests through a port-forward"), mcp.Enum("http", "https")),
+			mcp.WithString("mode", mcp.Description("How the request reaches the target: through the API server proxy subresource, or through a temporary port-forward to the Pod "+
+				"(a running and ready Pod is selected for Services) (Optional, proxy if not provided)"), mcp.Enum(kubernetes.HTTPProbeModeProxy, kubernetes.HTTPProbeModePortForward)),
+			mcp.WithNumber("timeout", mcp.Description(fmt.Sprintf("Maximum number of seconds to wait for the response (Optional, %d if not provided)", defaultHTTPProbeTimeout)), mcp.Min(1)),
+			mcp.WithNumber("maxBodyBytes", mcp.Description(fmt.Sprintf("Maximum number of bytes of the response body returned, the rest is truncated (Optional, %d if not provided)", defaultHTTPProbeMaxBodyBytes)), mcp.Min(1)),
+			// Tool annotations
+			mcp.WithTitleAnnotation("HTTP: Probe"),
+			mcp.WithReadOnlyHintAnnotation(false),   // Requests other than GET or HEAD might change the application state
+			mcp.WithDestructiveHintAnnotation(true), // DELETE, PUT, or PATCH requests might delete or overwrite application data
+			mcp.WithIdempotentHintAnnotation(false),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.httpProbe},
+	}
+}
+
+func (s *Server) httpProbe(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	options := kubernetes.HTTPProbeOptions{
+		Timeout:      defaultHTTPProbeTimeout * time.Second,
+		MaxBodyBytes: defaultHTTPProbeMaxBodyBytes,
+		Headers:      map[string]string{},
+	}
+	var ok bool
+	if options.Kind, ok = ctr.GetArguments()["kind"].(string); !ok || options.Kind == "" {
+		return NewTextResult("", errors.New("failed to probe, missing argument kind")), nil
+	}
+	if options.Name, ok = ctr.GetArguments()["name"].(string); !ok || options.Name == "" {
+		return NewTextResult("", errors.New("failed to probe, missing argument name")), nil
+	}
+	switch port := ctr.GetArguments()["port"].(type) {
+	case string:
+		options.Port = port
+	case float64:
+		options.Port = strconv.Itoa(int(port))
+	}
+	if options.Port == "" {
+		return NewTextResult("", errors.New("failed to probe, missing argument port")), nil
+	}
+	ns, _ := ctr.GetArguments()["namespace"].(string)
+	options.Path, _ = ctr.GetArguments()["path"].(string)
+	options.Method, _ = ctr.GetArguments()["method"].(string)
+	options.Method = strings.ToUpper(options.Method)
+	if options.Method != "" && !slices.Contains(httpProbeMethods, options.Method) {
+		return NewTextResult("", fmt.Errorf("failed to probe, method %s is not supported, supported methods are: %s", options.Method, strings.Join(httpProbeMethods, ", "))), nil
+	}
+	options.Body, _ = ctr.GetArguments()["body"].(string)
+	options.Scheme, _ = ctr.GetArguments()["scheme"].(string)
+	options.Mode, _ = ctr.GetArguments()["mode"].(string)
+	if headers, ok := ctr.GetArguments()["headers"].(map[string]interface{}); ok {
+		for key, value := range headers {
+			options.Headers[key] = fmt.Sprint(value)
+		}
+	}
+	if v, ok := ctr.GetArguments()["timeout"].(float64); ok {
+		if v < 1 {
+			return NewTextResult("", errors.New("failed to probe, timeout must be a positive number")), nil
+		}
+		options.Timeout = time.Duration(v * float64(time.Second))
+	}
+	if v, ok := ctr.GetArguments()["maxBodyBytes"].(float64); ok {
+		if v < 1 {
+			return NewTextResult("", errors.New("failed to probe, maxBodyBytes must be a positive number")), nil
+		}
+		options.MaxBodyBytes = int(v)
+	}
+	ret, err := s.k.Derived(ctx).HTTPProbe(ctx, ns, options)
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to probe %s %s port %s: %v", options.Kind, options.Name, options.Port, err)), nil
+	}
+	return NewTextResult(httpProbeOutput(ret, options), nil), nil
+}
+
+func httpProbeOutput(ret *kubernetes.HTTPProbeResult, options kubernetes.HTTPProbeOptions) string {
+	var out strings.Builder
+	out.WriteString(fmt.Sprintf("# HTTP probe of %s\n", ret.Target))
+	if len(ret.DroppedHeaders) > 0 {
+		out.WriteString(fmt.Sprintf("# Request headers not sent: %s\n", strings.Join(ret.DroppedHeaders, ", ")))
+	}
+	out.WriteString(fmt.Sprintf("# Response status: %s (%s)\n", ret.Status, ret.Duration.Round(time.Millisecond)))
+	out.WriteString("# Response headers\n")
+	headers := make([]string, 0, len(ret.Headers))
+	for key := range ret.Headers {
+		headers = append(headers, key)
+	}
+	slices.Sort(headers)
+	for _, key := range headers {
+		out.WriteString(key + ": " + strings.Join(ret.Headers[key], ", ") + "\n")
+	}
+	if ret.Body == "" {
+		out.WriteString("# Response body is empty\n")
+		return out.String()
+	}
+	out.WriteString("# Response body\n" + ret.Body)
+	if !strings.HasSuffix(ret.Body, "\n") {
+		out.WriteString("\n")
+	}
+	if ret.BodyTruncated {
+		out.WriteString(fmt.Sprintf("[... body truncated, exceeded %d bytes]\n", options.MaxBodyBytes))
+	}
+	return out.String()
+}
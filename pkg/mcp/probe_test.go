@@ -0,0 +1,167 @@
+package mcp
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/manusa/kubernetes-mcp-server/pkg/config"
+)
+
+func TestHTTPProbeProxy(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		mockServer := NewMockServer()
+		defer mockServer.Close()
+		c.withKubeConfig(mockServer.config)
+		mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			switch req.URL.Path {
+			case "/api/v1/namespaces/default/services/http:a-service:80/proxy/healthz":
+				w.Header().Set("Content-Type", "text/plain")
+				w.Header().Set("X-Method", req.Method)
+				w.Header().Set("X-Query", req.URL.RawQuery)
+				w.Header().Set("X-Custom", req.Header.Get("X-Custom"))
+				w.Header().Set("X-Impersonate-User", req.Header.Get("Impersonate-User"))
+				body, _ := io.ReadAll(req.Body)
+				_, _ = w.Write([]byte("ok " + string(body)))
+			case "/api/v1/namespaces/default/pods/https:a-pod:metrics/proxy/metrics":
+				w.WriteHeader(http.StatusServiceUnavailable)
+				_, _ = w.Write([]byte(strings.Repeat("m", 64)))
+			}
+		}))
+		t.Run("http_probe with missing port returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("http_probe", map[string]interface{}{"kind": "Service", "name": "a-service"})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "failed to probe, missing argument port" {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("http_probe with unsupported mode returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("http_probe", map[string]interface{}{"kind": "Service", "name": "a-service", "port": "80", "mode": "tunnel"})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			expected := "failed to probe Service a-service port 80: http probe mode tunnel is not supported, supported modes are: proxy, port-forward"
+			if toolResult.Content[0].(mcp.TextContent).Text != expected {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("http_probe service through proxy returns status, headers and body", func(t *testing.T) {
+			toolResult, err := c.callTool("http_probe", map[string]interface{}{
+				"kind": "Service", "name": "a-service", "namespace": "default", "port": 80,
+				"path": "/healthz?verbose=true", "method": "POST", "body": "ping", "headers": map[string]interface{}{"X-Custom": "custom-value"},
+			})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			text := toolResult.Content[0].(mcp.TextContent).Text
+			for _, expected := range []string{
+				"# HTTP probe of Service default/a-service port 80 (API server proxy)\n",
+				"# Response status: 200 OK",
+				"X-Method: POST\n",
+				"X-Query: verbose=true\n",
+				"X-Custom: custom-value\n",
+				"# Response body\nok ping\n",
+			} {
+				if !strings.Contains(text, expected) {
+					t.Errorf("expected %q in result, got %v", expected, text)
+				}
+			}
+		})
+		t.Run("http_probe through proxy doesn't send credential and impersonation headers", func(t *testing.T) {
+			toolResult, err := c.callTool("http_probe", map[string]interface{}{
+				"kind": "Service", "name": "a-service", "namespace": "default", "port": 80, "path": "/healthz",
+				"headers": map[string]interface{}{"Authorization": "Bearer a-token", "impersonate-user": "system:admin", "Connection": "close", "X-Custom": "sent"},
+			})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			text := toolResult.Content[0].(mcp.TextContent).Text
+			if !strings.Contains(text, "# Request headers not sent: Authorization, Connection, impersonate-user\n") {
+				t.Errorf("expected dropped headers in result, got %v", text)
+			}
+			if !strings.Contains(text, "X-Impersonate-User: \n") || !strings.Contains(text, "X-Custom: sent\n") {
+				t.Errorf("expected only the custom header to be sent, got %v", text)
+			}
+		})
+		t.Run("http_probe pod through proxy truncates the body", func(t *testing.T) {
+			toolResult, err := c.callTool("http_probe", map[string]interface{}{
+				"kind": "Pod", "name": "a-pod", "namespace": "default", "port": "metrics", "scheme": "https", "path": "metrics", "maxBodyBytes": 10,
+			})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			text := toolResult.Content[0].(mcp.TextContent).Text
+			if !strings.Contains(text, "# Response status: 503 Service Unavailable") {
+				t.Errorf("expected status in result, got %v", text)
+			}
+			if !strings.HasSuffix(text, "# Response body\nmmmmmmmmmm\n[... body truncated, exceeded 10 bytes]\n") {
+				t.Errorf("expected truncated body in result, got %v", text)
+			}
+		})
+	})
+}
+
+func TestHTTPProbePortForward(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		c.withEnvTest()
+		kc := c.newKubernetesClient()
+		_, _ = kc.CoreV1().Services("default").Create(c.ctx, &v1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "a-service-without-pods"},
+			Spec: v1.ServiceSpec{
+				Selector: map[string]string{"app": "not-running"},
+				Ports:    []v1.ServicePort{{Name: "http", Port: 80}},
+			},
+		}, metav1.CreateOptions{})
+		t.Run("http_probe port-forward to service without ready pods returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("http_probe", map[string]interface{}{"kind": "Service", "name": "a-service-without-pods", "port": "http", "mode": "port-forward"})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			expected := "failed to probe Service a-service-without-pods port http: service a-service-without-pods has no running and ready pods"
+			if toolResult.Content[0].(mcp.TextContent).Text != expected {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("http_probe port-forward to service with unknown port returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("http_probe", map[string]interface{}{"kind": "Service", "name": "a-service-without-pods", "port": "8080", "mode": "port-forward"})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			expected := "failed to probe Service a-service-without-pods port 8080: service a-service-without-pods has no port 8080"
+			if toolResult.Content[0].(mcp.TextContent).Text != expected {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("http_probe port-forward to pod with unknown named port returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("http_probe", map[string]interface{}{"kind": "Pod", "name": "a-pod-in-default", "port": "metrics", "mode": "port-forward"})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			expected := "failed to probe Pod a-pod-in-default port metrics: pod a-pod-in-default has no container port named metrics"
+			if toolResult.Content[0].(mcp.TextContent).Text != expected {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+	})
+}
+
+func TestHTTPProbeDenied(t *testing.T) {
+	deniedResourcesServer := &config.StaticConfig{DeniedResources: []config.GroupVersionKind{{Version: "v1", Kind: "Service"}}}
+	testCaseWithContext(t, &mcpContext{staticConfig: deniedResourcesServer}, func(c *mcpContext) {
+		c.withEnvTest()
+		toolResult, _ := c.callTool("http_probe", map[string]interface{}{"kind": "Service", "name": "a-service", "namespace": "default", "port": "80"})
+		t.Run("http_probe describes denial", func(t *testing.T) {
+			expectedMessage := "failed to probe Service a-service port 80: resource not allowed: /v1, Kind=Service"
+			if !toolResult.IsError || toolResult.Content[0].(mcp.TextContent).Text != expectedMessage {
+				t.Fatalf("expected descriptive error '%s', got %v", expectedMessage, toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+	})
+}
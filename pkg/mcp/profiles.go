@@ -10,6 +10,7 @@ type Profile interface {
 	GetName() string
 	GetDescription() string
 	GetTools(s *Server) []server.ServerTool
+	GetPrompts(s *Server) []server.ServerPrompt
 }
 
 var Profiles = []Profile{
@@ -40,12 +41,19 @@ func (p *FullProfile) GetTools(s *Server) []server.ServerTool {
 		s.initConfiguration(),
 		s.initEvents(),
 		s.initNamespaces(),
+		s.initNodes(),
 		s.initPods(),
+		s.initProbe(),
 		s.initResources(),
+		s.initRollout(),
 		s.initHelm(),
 	)
 }
 
+func (p *FullProfile) GetPrompts(s *Server) []server.ServerPrompt {
+	return s.initPrompts()
+}
+
 func init() {
 	ProfileNames = make([]string, 0)
 	for _, profile := range Profiles {
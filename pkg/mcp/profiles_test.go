@@ -12,21 +12,51 @@ func TestFullProfileTools(t *testing.T) {
 		"configuration_view",
 		"events_list",
 		"helm_install",
+		"helm_lint",
 		"helm_list",
+		"helm_template",
 		"helm_uninstall",
+		"helm_diff",
+		"helm_get",
+		"helm_history",
+		"helm_rollback",
+		"helm_status",
+		"helm_upgrade",
 		"namespaces_list",
+		"nodes_list",
+		"nodes_describe",
+		"nodes_top",
+		"nodes_log",
+		"nodes_stats_summary",
+		"nodes_cordon",
+		"nodes_uncordon",
+		"nodes_drain",
 		"pods_list",
 		"pods_list_in_namespace",
 		"pods_get",
 		"pods_delete",
 		"pods_top",
 		"pods_log",
+		"logs_aggregate",
 		"pods_run",
 		"pods_exec",
+		"pods_debug",
+		"pods_cp_from",
+		"pods_cp_to",
+		"http_probe",
 		"resources_list",
 		"resources_get",
 		"resources_create_or_update",
+		"resources_diff",
+		"resources_patch",
+		"resources_scale",
 		"resources_delete",
+		"rollout_status",
+		"rollout_history",
+		"rollout_restart",
+		"rollout_undo",
+		"rollout_pause",
+		"rollout_resume",
 	}
 	mcpCtx := &mcpContext{profile: &FullProfile{}}
 	testCaseWithContext(t, mcpCtx, func(c *mcpContext) {
@@ -0,0 +1,203 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/utils/ptr"
+
+	"github.com/manusa/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/manusa/kubernetes-mcp-server/pkg/output"
+)
+
+func (s *Server) initPrompts() []server.ServerPrompt {
+	return []server.ServerPrompt{
+		{Prompt: mcp.NewPrompt("debug-pod",
+			mcp.WithPromptDescription("Troubleshoot a Kubernetes Pod using its current definition, status, events, and logs"),
+			mcp.WithArgument("namespace", mcp.ArgumentDescription("Namespace of the Pod (Optional, current namespace if not provided)")),
+			mcp.WithArgument("name", mcp.ArgumentDescription("Name of the Pod to debug"), mcp.RequiredArgument()),
+			mcp.WithArgument("container", mcp.ArgumentDescription("Name of the Pod container to get the logs from (Optional)")),
+		), Handler: s.promptDebugPod},
+		{Prompt: mcp.NewPrompt("why-pending",
+			mcp.WithPromptDescription("Find out why a Kubernetes Pod is stuck in the Pending phase"),
+			mcp.WithArgument("namespace", mcp.ArgumentDescription("Namespace of the Pod (Optional, current namespace if not provided)")),
+			mcp.WithArgument("name", mcp.ArgumentDescription("Name of the pending Pod"), mcp.RequiredArgument()),
+		), Handler: s.promptWhyPending},
+		{Prompt: mcp.NewPrompt("crashloop-analysis",
+			mcp.WithPromptDescription("Analyze why a Kubernetes Pod container keeps crashing (CrashLoopBackOff)"),
+			mcp.WithArgument("namespace", mcp.ArgumentDescription("Namespace of the Pod (Optional, current namespace if not provided)")),
+			mcp.WithArgument("name", mcp.ArgumentDescription("Name of the crashing Pod"), mcp.RequiredArgument()),
+			mcp.WithArgument("container", mcp.ArgumentDescription("Name of the crashing container (Optional)")),
+		), Handler: s.promptCrashloopAnalysis},
+		{Prompt: mcp.NewPrompt("helm-release-health",
+			mcp.WithPromptDescription("Assess the health of a Helm release and the workloads it deployed"),
+			mcp.WithArgument("namespace", mcp.ArgumentDescription("Namespace of the Helm release (Optional, current namespace if not provided)")),
+			mcp.WithArgument("name", mcp.ArgumentDescription("Name of the Helm release"), mcp.RequiredArgument()),
+		), Handler: s.promptHelmReleaseHealth},
+		{Prompt: mcp.NewPrompt("namespace-overview",
+			mcp.WithPromptDescription("Summarize the workloads, their health, and the recent events in a Kubernetes namespace"),
+			mcp.WithArgument("namespace", mcp.ArgumentDescription("Namespace to summarize (Optional, current namespace if not provided)")),
+		), Handler: s.promptNamespaceOverview},
+	}
+}
+
+func (s *Server) promptDebugPod(ctx context.Context, gpr mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	k := s.k.Derived(ctx)
+	namespace := k.NamespaceOrDefault(gpr.Params.Arguments["namespace"])
+	name := gpr.Params.Arguments["name"]
+	if name == "" {
+		return nil, fmt.Errorf("failed to get prompt debug-pod, missing argument name")
+	}
+	return mcp.NewGetPromptResult("Debug Pod "+namespace+"/"+name, []mcp.PromptMessage{
+		promptMessage(fmt.Sprintf("The Pod %s in namespace %s is not working as expected. "+
+			"Analyze its definition, status, events, and logs provided below, identify the root cause, and propose a fix.", name, namespace)),
+		promptMessage(s.promptPod(ctx, k, namespace, name)),
+		promptMessage(s.promptEvents(ctx, k, namespace, "Pod", name)),
+		promptMessage(s.promptPodLog(ctx, k, namespace, name, gpr.Params.Arguments["container"], false)),
+	}), nil
+}
+
+func (s *Server) promptWhyPending(ctx context.Context, gpr mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	k := s.k.Derived(ctx)
+	namespace := k.NamespaceOrDefault(gpr.Params.Arguments["namespace"])
+	name := gpr.Params.Arguments["name"]
+	if name == "" {
+		return nil, fmt.Errorf("failed to get prompt why-pending, missing argument name")
+	}
+	return mcp.NewGetPromptResult("Why is Pod "+namespace+"/"+name+" pending", []mcp.PromptMessage{
+		promptMessage(fmt.Sprintf("The Pod %s in namespace %s is stuck in the Pending phase. "+
+			"Use its conditions and the scheduling events provided below to explain why it can't be scheduled or started "+
+			"(e.g. insufficient resources, taints, node selectors, affinity rules, unbound PersistentVolumeClaims, or image pull issues) and propose a fix.", name, namespace)),
+		promptMessage(s.promptPod(ctx, k, namespace, name)),
+		promptMessage(s.promptEvents(ctx, k, namespace, "Pod", name)),
+	}), nil
+}
+
+func (s *Server) promptCrashloopAnalysis(ctx context.Context, gpr mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	k := s.k.Derived(ctx)
+	namespace := k.NamespaceOrDefault(gpr.Params.Arguments["namespace"])
+	name := gpr.Params.Arguments["name"]
+	if name == "" {
+		return nil, fmt.Errorf("failed to get prompt crashloop-analysis, missing argument name")
+	}
+	return mcp.NewGetPromptResult("Crash loop analysis of Pod "+namespace+"/"+name, []mcp.PromptMessage{
+		promptMessage(fmt.Sprintf("A container of the Pod %s in namespace %s keeps crashing and restarting. "+
+			"Use the container statuses (last state, exit code, and restart count), events, and logs provided below to find out why the process exits "+
+			"(e.g. application errors, missing configuration, failing probes, or OOMKilled) and propose a fix.", name, namespace)),
+		promptMessage(s.promptPod(ctx, k, namespace, name)),
+		promptMessage(s.promptEvents(ctx, k, namespace, "Pod", name)),
+		promptMessage(s.promptPodLog(ctx, k, namespace, name, gpr.Params.Arguments["container"], false)),
+		promptMessage(s.promptPodLog(ctx, k, namespace, name, gpr.Params.Arguments["container"], true)),
+	}), nil
+}
+
+func (s *Server) promptHelmReleaseHealth(ctx context.Context, gpr mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	k := s.k.Derived(ctx)
+	namespace := k.NamespaceOrDefault(gpr.Params.Arguments["namespace"])
+	name := gpr.Params.Arguments["name"]
+	if name == "" {
+		return nil, fmt.Errorf("failed to get prompt helm-release-health, missing argument name")
+	}
+	status, err := k.NewHelm().Status(name, namespace, 0)
+	if err != nil {
+		status = fmt.Sprintf("failed to get helm release %s status in namespace %s: %v", name, namespace, err)
+	}
+	return mcp.NewGetPromptResult("Health of Helm release "+namespace+"/"+name, []mcp.PromptMessage{
+		promptMessage(fmt.Sprintf("Assess the health of the Helm release %s in namespace %s. "+
+			"Check the release status, revision, and the status of its resources, verify that the Pods deployed by the release (labeled app.kubernetes.io/instance=%s) are running and ready, "+
+			"and report any warning events.", name, namespace, name)),
+		promptMessage("# Helm release status (YAML)\n" + status),
+		promptMessage(s.promptPodsList(ctx, k, "Pods of the release", namespace, kubernetes.ResourceListOptions{
+			ListOptions: metav1.ListOptions{LabelSelector: "app.kubernetes.io/instance=" + name},
+			AsTable:     s.configuration.ListOutput.AsTable(),
+		})),
+		promptMessage(s.promptEvents(ctx, k, namespace, "", "")),
+	}), nil
+}
+
+func (s *Server) promptNamespaceOverview(ctx context.Context, gpr mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	k := s.k.Derived(ctx)
+	namespace := k.NamespaceOrDefault(gpr.Params.Arguments["namespace"])
+	return mcp.NewGetPromptResult("Overview of namespace "+namespace, []mcp.PromptMessage{
+		promptMessage(fmt.Sprintf("Provide an overview of the namespace %s. "+
+			"Summarize the running workloads, highlight any Pods that are not running or ready, and explain the relevant warning events.", namespace)),
+		promptMessage(s.promptPodsList(ctx, k, "Pods", namespace, kubernetes.ResourceListOptions{AsTable: s.configuration.ListOutput.AsTable()})),
+		promptMessage(s.promptEvents(ctx, k, namespace, "", "")),
+	}), nil
+}
+
+func (s *Server) promptPod(ctx context.Context, k *kubernetes.Kubernetes, namespace, name string) string {
+	pod, err := k.PodsGet(ctx, namespace, name)
+	if err != nil {
+		return fmt.Sprintf("failed to get pod %s in namespace %s: %v", name, namespace, err)
+	}
+	marshalledYaml, err := output.MarshalYaml(pod)
+	if err != nil {
+		return fmt.Sprintf("failed to get pod %s in namespace %s: %v", name, namespace, err)
+	}
+	return "# Pod definition and status (YAML)\n" + marshalledYaml
+}
+
+// promptEvents returns the events in the namespace, optionally filtered by the involved object kind and name
+func (s *Server) promptEvents(ctx context.Context, k *kubernetes.Kubernetes, namespace, kind, name string) string {
+	fieldSelector := fields.Set{}
+	if kind != "" {
+		fieldSelector["involvedObject.kind"] = kind
+	}
+	if name != "" {
+		fieldSelector["involvedObject.name"] = name
+	}
+	events, err := k.EventsList(ctx, namespace, metav1.ListOptions{FieldSelector: fieldSelector.String()})
+	if err != nil {
+		return fmt.Sprintf("failed to list events in namespace %s: %v", namespace, err)
+	}
+	if len(events) == 0 {
+		return "# Events\nNo events found"
+	}
+	marshalledYaml, err := output.MarshalYaml(events)
+	if err != nil {
+		return fmt.Sprintf("failed to list events in namespace %s: %v", namespace, err)
+	}
+	return "# Events (YAML)\n" + marshalledYaml
+}
+
+// promptPodLog returns the last lines logged by the pod container, or by its previous terminated instance if previous is true
+func (s *Server) promptPodLog(ctx context.Context, k *kubernetes.Kubernetes, namespace, name, container string, previous bool) string {
+	options := kubernetes.PodsLogOptions{}
+	options.Container = container
+	options.Previous = previous
+	options.TailLines = ptr.To(int64(defaultPodsLogTail))
+	logs, err := k.PodsLog(ctx, namespace, name, options)
+	title := "# Logs (last lines)\n"
+	if previous {
+		title = "# Logs of the previous terminated container (last lines)\n"
+	}
+	if err != nil {
+		return fmt.Sprintf("%sfailed to get pod %s log in namespace %s: %v", title, name, namespace, err)
+	}
+	if strings.TrimSpace(logs) == "" {
+		return title + "The pod has not logged any message"
+	}
+	return title + logs
+}
+
+func (s *Server) promptPodsList(ctx context.Context, k *kubernetes.Kubernetes, title, namespace string, options kubernetes.ResourceListOptions) string {
+	ret, err := k.PodsListInNamespace(ctx, namespace, options)
+	if err != nil {
+		return fmt.Sprintf("failed to list pods in namespace %s: %v", namespace, err)
+	}
+	printed, err := s.configuration.ListOutput.PrintObj(ret)
+	if err != nil {
+		return fmt.Sprintf("failed to list pods in namespace %s: %v", namespace, err)
+	}
+	return "# " + title + "\n" + printed
+}
+
+func promptMessage(text string) mcp.PromptMessage {
+	return mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent(text))
+}
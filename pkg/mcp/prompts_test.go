@@ -0,0 +1,123 @@
+package mcp
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPromptsList(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		prompts, err := c.mcpClient.ListPrompts(c.ctx, mcp.ListPromptsRequest{})
+		t.Run("ListPrompts returns prompts", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("call ListPrompts failed %v", err)
+			}
+		})
+		expectedNames := []string{"debug-pod", "why-pending", "crashloop-analysis", "helm-release-health", "namespace-overview"}
+		t.Run("ListPrompts returns built-in prompts", func(t *testing.T) {
+			if len(prompts.Prompts) != len(expectedNames) {
+				t.Fatalf("invalid prompt count, expected %d, got %d", len(expectedNames), len(prompts.Prompts))
+			}
+			for _, name := range expectedNames {
+				found := false
+				for _, prompt := range prompts.Prompts {
+					if prompt.Name == name {
+						found = true
+						break
+					}
+				}
+				if !found {
+					t.Fatalf("prompt %s not found", name)
+				}
+			}
+		})
+	})
+}
+
+func TestPromptsDebugPod(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		c.withEnvTest()
+		t.Run("debug-pod with nil name returns error", func(t *testing.T) {
+			_, err := c.getPrompt("debug-pod", map[string]string{})
+			if err == nil {
+				t.Fatalf("get prompt should fail")
+			}
+		})
+		result, err := c.getPrompt("debug-pod", map[string]string{"namespace": "ns-1", "name": "a-pod-in-ns-1"})
+		t.Run("debug-pod returns prompt", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("get prompt failed %v", err)
+			}
+			if len(result.Messages) != 4 {
+				t.Fatalf("invalid message count, expected 4, got %d", len(result.Messages))
+			}
+		})
+		t.Run("debug-pod includes pod definition", func(t *testing.T) {
+			podYaml := result.Messages[1].Content.(mcp.TextContent).Text
+			if !strings.HasPrefix(podYaml, "# Pod definition and status (YAML)") || !strings.Contains(podYaml, "name: a-pod-in-ns-1") {
+				t.Fatalf("invalid pod definition, got %v", podYaml)
+			}
+		})
+	})
+}
+
+func TestPromptsNamespaceOverview(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		c.withEnvTest()
+		result, err := c.getPrompt("namespace-overview", map[string]string{"namespace": "ns-1"})
+		t.Run("namespace-overview returns prompt", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("get prompt failed %v", err)
+			}
+		})
+		t.Run("namespace-overview includes pods", func(t *testing.T) {
+			pods := result.Messages[1].Content.(mcp.TextContent).Text
+			if !strings.Contains(pods, "a-pod-in-ns-1") {
+				t.Fatalf("invalid pods, got %v", pods)
+			}
+		})
+	})
+}
+
+func TestPromptsHelmReleaseHealth(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		c.withEnvTest()
+		kc := c.newKubernetesClient()
+		clearHelmReleases(c.ctx, kc)
+		for _, name := range []string{"release-to-assess", "another-release"} {
+			_, _ = kc.CoreV1().Secrets("default").Create(c.ctx, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "sh.helm.release.v1." + name + ".v1",
+					Labels: map[string]string{"owner": "helm", "name": name, "version": "1"},
+				},
+				Data: map[string][]byte{
+					"release": []byte(base64.StdEncoding.EncodeToString([]byte("{" +
+						"\"name\":\"" + name + "\"," +
+						"\"version\":1," +
+						"\"info\":{\"status\":\"deployed\"}" +
+						"}"))),
+				},
+			}, metav1.CreateOptions{})
+		}
+		result, err := c.getPrompt("helm-release-health", map[string]string{"name": "release-to-assess"})
+		t.Run("helm-release-health returns prompt", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("get prompt failed %v", err)
+			}
+		})
+		t.Run("helm-release-health includes the status of the named release only", func(t *testing.T) {
+			status := result.Messages[1].Content.(mcp.TextContent).Text
+			if !strings.HasPrefix(status, "# Helm release status (YAML)") || !strings.Contains(status, "name: release-to-assess") {
+				t.Fatalf("invalid release status, got %v", status)
+			}
+			if strings.Contains(status, "another-release") {
+				t.Fatalf("unexpected release in status, got %v", status)
+			}
+		})
+	})
+}
@@ -0,0 +1,266 @@
+package mcp
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/klog/v2"
+
+	"github.com/manusa/kubernetes-mcp-server/pkg/kubernetes"
+)
+
+// resourceSubscriptionKey identifies a watch, watches are shared by the sessions subscribed to the same URI with the same identity
+type resourceSubscriptionKey struct {
+	uri      string
+	identity string
+}
+
+type resourceSubscription struct {
+	cancel   context.CancelFunc
+	sessions map[string]struct{}
+}
+
+// resourceSubscriptions keeps track of the Kubernetes watches backing the MCP resource subscriptions
+type resourceSubscriptions struct {
+	mu            sync.Mutex
+	subscriptions map[resourceSubscriptionKey]*resourceSubscription
+}
+
+func newResourceSubscriptions() *resourceSubscriptions {
+	return &resourceSubscriptions{subscriptions: make(map[resourceSubscriptionKey]*resourceSubscription)}
+}
+
+func (s *Server) initHooks() *server.Hooks {
+	hooks := &server.Hooks{}
+	// Subscriptions are started before the request is handled, the hooks after the subscribe request can't report errors
+	hooks.AddOnRequestInitialization(func(ctx context.Context, _ any, message any) error {
+		raw, ok := message.(json.RawMessage)
+		if !ok {
+			return nil
+		}
+		request := mcp.SubscribeRequest{}
+		if err := json.Unmarshal(raw, &request); err != nil || request.Method != string(mcp.MethodResourcesSubscribe) || request.Params.URI == "" {
+			return nil
+		}
+		return s.resourceSubscribe(ctx, request.Params.URI)
+	})
+	hooks.AddAfterUnsubscribe(func(ctx context.Context, _ any, message *mcp.UnsubscribeRequest, _ *mcp.EmptyResult) {
+		s.resourceUnsubscribe(ctx, message.Params.URI)
+	})
+	hooks.AddOnUnregisterSession(func(_ context.Context, session server.ClientSession) {
+		s.resourceSubscriptionsRemoveSession(session.SessionID())
+	})
+	return hooks
+}
+
+// resourceSubscribe subscribes the session to the resource, the subscription is rejected if the URI is invalid or the
+// resource can't be watched
+func (s *Server) resourceSubscribe(ctx context.Context, uri string) error {
+	session := server.ClientSessionFromContext(ctx)
+	if session == nil {
+		return nil
+	}
+	authorization, _ := ctx.Value(kubernetes.AuthorizationHeader).(string)
+	key := resourceSubscriptionKey{uri: uri, identity: identityOf(authorization)}
+	if s.subscriptions.join(key, session.SessionID()) {
+		return nil
+	}
+	arguments, err := s.resourceTemplateMatch(uri)
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to resource %s: %v", uri, err)
+	}
+	gvk, err := parseGroupVersionKind(arguments)
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to resource %s: %v", uri, err)
+	}
+	namespace, name := arguments["namespace"].(string), arguments["name"].(string)
+	// The watch outlives the subscribe request, only the authorization is preserved
+	watchCtx, cancel := context.WithCancel(context.WithValue(context.Background(), kubernetes.AuthorizationHeader, authorization))
+	// The client is captured once, the server replaces it when the kubeconfig changes
+	k := s.k.Derived(watchCtx)
+	w, err := k.ResourcesWatch(watchCtx, gvk, namespace, name)
+	if err != nil {
+		cancel()
+		return fmt.Errorf("failed to subscribe to resource %s: %v", uri, err)
+	}
+	s.subscriptions.mu.Lock()
+	defer s.subscriptions.mu.Unlock()
+	// Another session might have subscribed to the same resource in the meantime
+	if subscription, ok := s.subscriptions.subscriptions[key]; ok {
+		w.Stop()
+		cancel()
+		subscription.sessions[session.SessionID()] = struct{}{}
+		return nil
+	}
+	subscription := &resourceSubscription{cancel: cancel, sessions: map[string]struct{}{session.SessionID(): {}}}
+	s.subscriptions.subscriptions[key] = subscription
+	go s.resourceWatch(watchCtx, key, subscription, w, func() (watch.Interface, error) {
+		return k.ResourcesWatch(watchCtx, gvk, namespace, name)
+	})
+	return nil
+}
+
+// resourceWatch notifies the subscribed sessions of the changes reported by the watch until the subscription is released.
+// The watch is restarted with a capped exponential backoff when it's closed or fails, the subscription is dropped if
+// the failure is not retryable (e.g. forbidden or not found).
+func (s *Server) resourceWatch(ctx context.Context, key resourceSubscriptionKey, subscription *resourceSubscription, w watch.Interface, restart func() (watch.Interface, error)) {
+	backoff := resourceWatchBackoff()
+	var err error
+	for {
+		if err == nil {
+			var notified bool
+			if notified, err = s.resourceWatchNotify(key, w); notified {
+				backoff = resourceWatchBackoff()
+			}
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil && !resourceWatchRetryable(err) {
+			klog.V(1).Infof("failed to watch resource %s, dropping the subscription: %v", key.uri, err)
+			s.subscriptions.drop(key, subscription)
+			return
+		} else if err != nil {
+			klog.V(1).Infof("failed to watch resource %s: %v", key.uri, err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff.Step()):
+		}
+		w, err = restart()
+	}
+}
+
+// resourceWatchNotify notifies the changes reported by the watch until it's closed, returns whether any change was
+// notified and the error reported by the watch
+func (s *Server) resourceWatchNotify(key resourceSubscriptionKey, w watch.Interface) (bool, error) {
+	defer w.Stop()
+	notified := false
+	for event := range w.ResultChan() {
+		switch event.Type {
+		case watch.Bookmark:
+			continue
+		case watch.Error:
+			return notified, apierrors.FromObject(event.Object)
+		}
+		s.resourceUpdated(key)
+		notified = true
+	}
+	return notified, nil
+}
+
+// resourceWatchBackoff is the delay before restarting a closed or failed watch, from 1 second up to 1 minute
+func resourceWatchBackoff() wait.Backoff {
+	return wait.Backoff{Duration: time.Second, Factor: 2, Jitter: 0.1, Steps: math.MaxInt32, Cap: time.Minute}
+}
+
+// resourceWatchRetryable returns false for the errors that won't be solved by restarting the watch (e.g. denied or
+// non-existent kinds)
+func resourceWatchRetryable(err error) bool {
+	switch {
+	case apierrors.IsForbidden(err), apierrors.IsUnauthorized(err), apierrors.IsNotFound(err),
+		apierrors.IsMethodNotSupported(err), apierrors.IsBadRequest(err), apierrors.IsInvalid(err), meta.IsNoMatchError(err):
+		return false
+	}
+	return !strings.HasPrefix(err.Error(), "resource not allowed: ")
+}
+
+func (s *Server) resourceUnsubscribe(ctx context.Context, uri string) {
+	session := server.ClientSessionFromContext(ctx)
+	if session == nil {
+		return
+	}
+	authorization, _ := ctx.Value(kubernetes.AuthorizationHeader).(string)
+	s.subscriptions.mu.Lock()
+	defer s.subscriptions.mu.Unlock()
+	s.subscriptions.release(resourceSubscriptionKey{uri: uri, identity: identityOf(authorization)}, session.SessionID())
+}
+
+func (s *Server) resourceSubscriptionsRemoveSession(sessionID string) {
+	s.subscriptions.mu.Lock()
+	defer s.subscriptions.mu.Unlock()
+	for key := range s.subscriptions.subscriptions {
+		s.subscriptions.release(key, sessionID)
+	}
+}
+
+// resourceUpdated notifies the subscribed sessions that the resource identified by key has changed
+func (s *Server) resourceUpdated(key resourceSubscriptionKey) {
+	s.subscriptions.mu.Lock()
+	sessions := make([]string, 0)
+	if subscription, ok := s.subscriptions.subscriptions[key]; ok {
+		for sessionID := range subscription.sessions {
+			sessions = append(sessions, sessionID)
+		}
+	}
+	s.subscriptions.mu.Unlock()
+	for _, sessionID := range sessions {
+		_ = s.server.SendNotificationToSpecificClient(sessionID, mcp.MethodNotificationResourceUpdated, map[string]any{"uri": key.uri})
+	}
+}
+
+// join adds the session to the existing subscription, returns false if there's no subscription for the key
+func (r *resourceSubscriptions) join(key resourceSubscriptionKey, sessionID string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	subscription, ok := r.subscriptions[key]
+	if ok {
+		subscription.sessions[sessionID] = struct{}{}
+	}
+	return ok
+}
+
+// drop stops the watch and removes the subscription of all the sessions, unless it was already replaced by a new one
+func (r *resourceSubscriptions) drop(key resourceSubscriptionKey, subscription *resourceSubscription) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	subscription.cancel()
+	if r.subscriptions[key] == subscription {
+		delete(r.subscriptions, key)
+	}
+}
+
+// release removes the session from the subscription and stops the watch when no sessions are left (caller must hold the lock)
+func (r *resourceSubscriptions) release(key resourceSubscriptionKey, sessionID string) {
+	subscription, ok := r.subscriptions[key]
+	if !ok {
+		return
+	}
+	delete(subscription.sessions, sessionID)
+	if len(subscription.sessions) == 0 {
+		subscription.cancel()
+		delete(r.subscriptions, key)
+	}
+}
+
+func (r *resourceSubscriptions) close() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for key, subscription := range r.subscriptions {
+		subscription.cancel()
+		delete(r.subscriptions, key)
+	}
+}
+
+// identityOf returns a digest of the provided authorization so that tokens are not kept as map keys
+func identityOf(authorization string) string {
+	if authorization == "" {
+		return ""
+	}
+	digest := sha256.Sum256([]byte(authorization))
+	return hex.EncodeToString(digest[:])
+}
@@ -0,0 +1,183 @@
+package mcp
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestResourceSubscriptions(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		c.withEnvTest()
+		var mu sync.Mutex
+		notifications := make([]mcp.JSONRPCNotification, 0)
+		c.mcpClient.OnNotification(func(n mcp.JSONRPCNotification) {
+			mu.Lock()
+			defer mu.Unlock()
+			if n.Method == mcp.MethodNotificationResourceUpdated {
+				notifications = append(notifications, n)
+			}
+		})
+		uri := "k8s://fake-context/v1/ConfigMap/default/a-configmap-to-watch"
+		subscribeRequest := mcp.SubscribeRequest{}
+		subscribeRequest.Params.URI = uri
+		err := c.mcpClient.Subscribe(c.ctx, subscribeRequest)
+		t.Run("Subscribe succeeds", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("subscribe failed %v", err)
+			}
+		})
+		t.Run("Subscribe starts a single watch", func(t *testing.T) {
+			_ = c.mcpClient.Subscribe(c.ctx, subscribeRequest)
+			c.mcpServer.subscriptions.mu.Lock()
+			defer c.mcpServer.subscriptions.mu.Unlock()
+			if len(c.mcpServer.subscriptions.subscriptions) != 1 {
+				t.Fatalf("invalid watch count, expected 1, got %d", len(c.mcpServer.subscriptions.subscriptions))
+			}
+		})
+		// Wait for the watch to be established
+		time.Sleep(500 * time.Millisecond)
+		kc := c.newKubernetesClient()
+		_, _ = kc.CoreV1().ConfigMaps("default").Create(c.ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "a-configmap-to-watch"},
+		}, metav1.CreateOptions{})
+		_, _ = kc.CoreV1().ConfigMaps("default").Create(c.ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "a-configmap-not-to-watch"},
+		}, metav1.CreateOptions{})
+		t.Run("Subscribe notifies resource update", func(t *testing.T) {
+			deadline := time.Now().Add(5 * time.Second)
+			for time.Now().Before(deadline) {
+				mu.Lock()
+				received := len(notifications)
+				mu.Unlock()
+				if received > 0 {
+					break
+				}
+				time.Sleep(100 * time.Millisecond)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if len(notifications) != 1 {
+				t.Fatalf("invalid notification count, expected 1, got %d", len(notifications))
+			}
+			if notifications[0].Params.AdditionalFields["uri"] != uri {
+				t.Fatalf("invalid notification uri, expected %s, got %v", uri, notifications[0].Params.AdditionalFields["uri"])
+			}
+		})
+		unsubscribeRequest := mcp.UnsubscribeRequest{}
+		unsubscribeRequest.Params.URI = uri
+		err = c.mcpClient.Unsubscribe(c.ctx, unsubscribeRequest)
+		t.Run("Unsubscribe stops the watch", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("unsubscribe failed %v", err)
+			}
+			c.mcpServer.subscriptions.mu.Lock()
+			defer c.mcpServer.subscriptions.mu.Unlock()
+			if len(c.mcpServer.subscriptions.subscriptions) != 0 {
+				t.Fatalf("invalid watch count, expected 0, got %d", len(c.mcpServer.subscriptions.subscriptions))
+			}
+		})
+	})
+}
+
+func TestResourceSubscriptionsSessionEnd(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		c.withEnvTest()
+		subscribeRequest := mcp.SubscribeRequest{}
+		subscribeRequest.Params.URI = "k8s://fake-context/v1/ConfigMap/default"
+		_ = c.mcpClient.Subscribe(c.ctx, subscribeRequest)
+		_ = c.mcpClient.Close()
+		t.Run("Session end stops the watch", func(t *testing.T) {
+			watches := -1
+			deadline := time.Now().Add(5 * time.Second)
+			for time.Now().Before(deadline) && watches != 0 {
+				c.mcpServer.subscriptions.mu.Lock()
+				watches = len(c.mcpServer.subscriptions.subscriptions)
+				c.mcpServer.subscriptions.mu.Unlock()
+				time.Sleep(100 * time.Millisecond)
+			}
+			if watches != 0 {
+				t.Fatalf("invalid watch count, expected 0, got %d", watches)
+			}
+		})
+	})
+}
+
+func TestResourceSubscriptionsInvalid(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		mockServer := NewMockServer()
+		defer mockServer.Close()
+		c.withKubeConfig(mockServer.config)
+		mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch req.URL.Path {
+			// Request Performed by DiscoveryClient to Kube API (Get API Groups legacy -core-)
+			case "/api":
+				_, _ = w.Write([]byte(`{"kind":"APIVersions","versions":["v1"],"serverAddressByClientCIDRs":[{"clientCIDR":"0.0.0.0/0"}]}`))
+			// Request Performed by DiscoveryClient to Kube API (Get API Groups)
+			case "/apis":
+				_, _ = w.Write([]byte(`{"kind":"APIGroupList","apiVersion":"v1","groups":[]}`))
+			// Request Performed by DiscoveryClient to Kube API (Get API Resources)
+			case "/api/v1":
+				_, _ = w.Write([]byte(`{"kind":"APIResourceList","apiVersion":"v1","resources":[{"name":"configmaps","singularName":"","namespaced":true,"kind":"ConfigMap","verbs":["get","list","watch"]}]}`))
+			case "/api/v1/namespaces/default/configmaps":
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"Forbidden","code":403}`))
+			}
+		}))
+		for _, tc := range []struct{ name, uri string }{
+			{"Subscribe to invalid URI returns error", "k8s://fake-context/v1"},
+			{"Subscribe to unknown kind returns error", "k8s://fake-context/v1/Unknown/default"},
+			{"Subscribe to forbidden resource returns error", "k8s://fake-context/v1/ConfigMap/default"},
+		} {
+			subscribeRequest := mcp.SubscribeRequest{}
+			subscribeRequest.Params.URI = tc.uri
+			err := c.mcpClient.Subscribe(c.ctx, subscribeRequest)
+			t.Run(tc.name, func(t *testing.T) {
+				if err == nil {
+					t.Fatalf("expected subscribe to fail for %s", tc.uri)
+				}
+			})
+		}
+		t.Run("Rejected subscriptions don't start a watch", func(t *testing.T) {
+			c.mcpServer.subscriptions.mu.Lock()
+			defer c.mcpServer.subscriptions.mu.Unlock()
+			if len(c.mcpServer.subscriptions.subscriptions) != 0 {
+				t.Fatalf("invalid watch count, expected 0, got %d", len(c.mcpServer.subscriptions.subscriptions))
+			}
+		})
+	})
+}
+
+func TestResourceWatchRetryable(t *testing.T) {
+	configMaps := schema.GroupResource{Resource: "configmaps"}
+	for _, tc := range []struct {
+		name      string
+		err       error
+		retryable bool
+	}{
+		{"server timeout", apierrors.NewServerTimeout(configMaps, "watch", 1), true},
+		{"internal error", apierrors.NewInternalError(errors.New("etcd unavailable")), true},
+		{"connection error", errors.New("connection refused"), true},
+		{"forbidden", apierrors.NewForbidden(configMaps, "", errors.New("denied")), false},
+		{"unauthorized", apierrors.NewUnauthorized("expired token"), false},
+		{"not found", apierrors.NewNotFound(configMaps, ""), false},
+		{"no match", &meta.NoKindMatchError{GroupKind: schema.GroupKind{Kind: "Unknown"}}, false},
+		{"not allowed", errors.New("resource not allowed: /v1, Kind=Secret"), false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if retryable := resourceWatchRetryable(tc.err); retryable != tc.retryable {
+				t.Errorf("expected retryable %t, got %t", tc.retryable, retryable)
+			}
+		})
+	}
+}
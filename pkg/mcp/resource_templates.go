@@ -0,0 +1,123 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/manusa/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/manusa/kubernetes-mcp-server/pkg/output"
+)
+
+const (
+	// resourceTemplateObject is the URI template to retrieve a single Kubernetes object.
+	// apiVersion must be percent-encoded for non-core groups (e.g. apps%2Fv1), namespace is empty for cluster scoped objects.
+	resourceTemplateObject = "k8s://{context}/{apiVersion}/{kind}/{namespace}/{name}"
+	// resourceTemplateList is the URI template to retrieve a list of Kubernetes objects.
+	// namespace is empty to list cluster scoped objects or objects from all namespaces.
+	resourceTemplateList = "k8s://{context}/{apiVersion}/{kind}/{namespace}"
+	resourceMIMEType     = "application/yaml"
+)
+
+func (s *Server) initResourceTemplates() {
+	s.server.AddResourceTemplate(mcp.NewResourceTemplate(resourceTemplateObject, "Kubernetes Object",
+		mcp.WithTemplateDescription("A Kubernetes object in the current cluster identified by its apiVersion, kind, namespace (empty for cluster scoped objects), and name. "+
+			"Non-core apiVersion values must be percent-encoded (e.g. apps%2Fv1)"),
+		mcp.WithTemplateMIMEType(resourceMIMEType),
+	), s.resourceTemplateGet)
+	s.server.AddResourceTemplate(mcp.NewResourceTemplate(resourceTemplateList, "Kubernetes Object List",
+		mcp.WithTemplateDescription("A list of Kubernetes objects in the current cluster identified by their apiVersion, kind, and namespace (empty for all namespaces or cluster scoped objects). "+
+			"Non-core apiVersion values must be percent-encoded (e.g. apps%2Fv1)"),
+		mcp.WithTemplateMIMEType(resourceMIMEType),
+	), s.resourceTemplateList)
+}
+
+func (s *Server) resourceTemplateGet(ctx context.Context, rr mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	arguments, err := s.resourceTemplateArguments(rr.Params.Arguments)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read resource %s, %s", rr.Params.URI, err)
+	}
+	if arguments["name"] == "" {
+		return nil, fmt.Errorf("failed to read resource %s, missing argument name", rr.Params.URI)
+	}
+	gvk, err := parseGroupVersionKind(arguments)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read resource %s, %s", rr.Params.URI, err)
+	}
+	ret, err := s.k.Derived(ctx).ResourcesGet(ctx, gvk, arguments["namespace"].(string), arguments["name"].(string))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read resource %s: %v", rr.Params.URI, err)
+	}
+	return newResourceContents(rr.Params.URI, ret)
+}
+
+func (s *Server) resourceTemplateList(ctx context.Context, rr mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	arguments, err := s.resourceTemplateArguments(rr.Params.Arguments)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read resource %s, %s", rr.Params.URI, err)
+	}
+	gvk, err := parseGroupVersionKind(arguments)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read resource %s, %s", rr.Params.URI, err)
+	}
+	ret, err := s.k.Derived(ctx).ResourcesList(ctx, gvk, arguments["namespace"].(string), kubernetes.ResourceListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read resource %s: %v", rr.Params.URI, err)
+	}
+	return newResourceContents(rr.Params.URI, ret)
+}
+
+// resourceTemplateMatch returns the resource template arguments for the provided URI
+func (s *Server) resourceTemplateMatch(uri string) (map[string]interface{}, error) {
+	for _, template := range []string{resourceTemplateObject, resourceTemplateList} {
+		uriTemplate := mcp.NewResourceTemplate(template, "").URITemplate
+		if !uriTemplate.Regexp().MatchString(uri) {
+			continue
+		}
+		matched := make(map[string]any)
+		for name, value := range uriTemplate.Match(uri) {
+			matched[name] = value.V
+		}
+		return s.resourceTemplateArguments(matched)
+	}
+	return nil, fmt.Errorf("%s does not match any of the supported resource templates", uri)
+}
+
+// resourceTemplateArguments flattens the URI template matched variables and validates the requested context
+func (s *Server) resourceTemplateArguments(matched map[string]any) (map[string]interface{}, error) {
+	arguments := make(map[string]interface{}, len(matched))
+	for _, name := range []string{"context", "apiVersion", "kind", "namespace", "name"} {
+		arguments[name] = ""
+		switch v := matched[name].(type) {
+		case string:
+			arguments[name] = v
+		case []string:
+			if len(v) > 0 {
+				arguments[name] = v[0]
+			}
+		}
+	}
+	if arguments["kind"] == "" {
+		return nil, errors.New("missing argument kind")
+	}
+	currentContext, err := s.k.CurrentContext()
+	if err != nil {
+		return nil, err
+	}
+	if arguments["context"] != currentContext {
+		return nil, fmt.Errorf("context %s is not the current context (%s)", arguments["context"], currentContext)
+	}
+	return arguments, nil
+}
+
+func newResourceContents(uri string, obj any) ([]mcp.ResourceContents, error) {
+	marshalledYaml, err := output.MarshalYaml(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read resource %s: %v", uri, err)
+	}
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{URI: uri, MIMEType: resourceMIMEType, Text: marshalledYaml},
+	}, nil
+}
@@ -0,0 +1,153 @@
+package mcp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+
+	"github.com/manusa/kubernetes-mcp-server/pkg/config"
+)
+
+func TestResourceTemplatesList(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		templates, err := c.mcpClient.ListResourceTemplates(c.ctx, mcp.ListResourceTemplatesRequest{})
+		t.Run("ListResourceTemplates returns templates", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("call ListResourceTemplates failed %v", err)
+			}
+			if len(templates.ResourceTemplates) != 2 {
+				t.Fatalf("invalid resource template count, expected 2, got %v", len(templates.ResourceTemplates))
+			}
+		})
+		for _, expected := range []string{resourceTemplateObject, resourceTemplateList} {
+			t.Run("ListResourceTemplates has "+expected, func(t *testing.T) {
+				for _, template := range templates.ResourceTemplates {
+					if template.URITemplate.Raw() == expected {
+						return
+					}
+				}
+				t.Fatalf("resource template %s not found", expected)
+			})
+		}
+	})
+}
+
+func TestResourceTemplatesRead(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		c.withEnvTest()
+		t.Run("ReadResource with unknown context returns error", func(t *testing.T) {
+			_, err := c.readResource("k8s://other-context/v1/Namespace//default")
+			if err == nil {
+				t.Fatalf("read resource should fail")
+			}
+			if !strings.Contains(err.Error(), "context other-context is not the current context (fake-context)") {
+				t.Fatalf("invalid error message, got %v", err)
+			}
+		})
+		t.Run("ReadResource with nonexistent kind returns error", func(t *testing.T) {
+			_, err := c.readResource("k8s://fake-context/v1/NonExistent/default/a-name")
+			if err == nil {
+				t.Fatalf("read resource should fail")
+			}
+		})
+		namespace, err := c.readResource("k8s://fake-context/v1/Namespace//default")
+		t.Run("ReadResource returns cluster scoped object", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("read resource failed %v", err)
+			}
+			if len(namespace.Contents) != 1 {
+				t.Fatalf("invalid contents count, expected 1, got %v", len(namespace.Contents))
+			}
+			contents := namespace.Contents[0].(mcp.TextResourceContents)
+			if contents.MIMEType != "application/yaml" {
+				t.Fatalf("invalid mime type, expected application/yaml, got %v", contents.MIMEType)
+			}
+			var decoded unstructured.Unstructured
+			if err = yaml.Unmarshal([]byte(contents.Text), &decoded); err != nil {
+				t.Fatalf("invalid resource content %v", err)
+			}
+			if decoded.GetName() != "default" {
+				t.Fatalf("invalid namespace name, expected default, got %v", decoded.GetName())
+			}
+		})
+		pod, err := c.readResource("k8s://fake-context/v1/Pod/ns-1/a-pod-in-ns-1")
+		t.Run("ReadResource returns namespaced object", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("read resource failed %v", err)
+			}
+			var decoded unstructured.Unstructured
+			if err = yaml.Unmarshal([]byte(pod.Contents[0].(mcp.TextResourceContents).Text), &decoded); err != nil {
+				t.Fatalf("invalid resource content %v", err)
+			}
+			if decoded.GetName() != "a-pod-in-ns-1" || decoded.GetNamespace() != "ns-1" {
+				t.Fatalf("invalid pod, expected ns-1/a-pod-in-ns-1, got %v/%v", decoded.GetNamespace(), decoded.GetName())
+			}
+			if decoded.GetManagedFields() != nil {
+				t.Fatalf("managed fields should be omitted")
+			}
+		})
+		pods, err := c.readResource("k8s://fake-context/v1/Pod/ns-1")
+		t.Run("ReadResource returns list of namespaced objects", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("read resource failed %v", err)
+			}
+			var decoded []unstructured.Unstructured
+			if err = yaml.Unmarshal([]byte(pods.Contents[0].(mcp.TextResourceContents).Text), &decoded); err != nil {
+				t.Fatalf("invalid resource content %v", err)
+			}
+			if len(decoded) != 1 || decoded[0].GetName() != "a-pod-in-ns-1" {
+				t.Fatalf("invalid pod list, expected a-pod-in-ns-1, got %v", decoded)
+			}
+		})
+		roles, err := c.readResource("k8s://fake-context/rbac.authorization.k8s.io%2Fv1/ClusterRole/")
+		t.Run("ReadResource with percent-encoded apiVersion returns list", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("read resource failed %v", err)
+			}
+			if !strings.Contains(roles.Contents[0].(mcp.TextResourceContents).Text, "kind: ClusterRole") {
+				t.Fatalf("invalid cluster role list, got %v", roles.Contents[0].(mcp.TextResourceContents).Text)
+			}
+		})
+	})
+}
+
+func TestResourceTemplatesReadDenied(t *testing.T) {
+	deniedResourcesServer := &config.StaticConfig{
+		DeniedResources: []config.GroupVersionKind{
+			{Version: "v1", Kind: "Secret"},
+			{Group: "rbac.authorization.k8s.io", Version: "v1"},
+		},
+	}
+	testCaseWithContext(t, &mcpContext{staticConfig: deniedResourcesServer}, func(c *mcpContext) {
+		c.withEnvTest()
+		_, deniedByKind := c.readResource("k8s://fake-context/v1/Secret/default")
+		t.Run("ReadResource (denied by kind) describes denial", func(t *testing.T) {
+			if deniedByKind == nil {
+				t.Fatalf("read resource should fail")
+			}
+			expectedMessage := "failed to read resource k8s://fake-context/v1/Secret/default: resource not allowed: /v1, Kind=Secret"
+			if !strings.Contains(deniedByKind.Error(), expectedMessage) {
+				t.Fatalf("expected descriptive error '%s', got %v", expectedMessage, deniedByKind)
+			}
+		})
+		_, deniedByGroup := c.readResource("k8s://fake-context/rbac.authorization.k8s.io%2Fv1/Role/default/a-role")
+		t.Run("ReadResource (denied by group) describes denial", func(t *testing.T) {
+			if deniedByGroup == nil {
+				t.Fatalf("read resource should fail")
+			}
+			expectedMessage := "resource not allowed: rbac.authorization.k8s.io/v1, Kind=Role"
+			if !strings.Contains(deniedByGroup.Error(), expectedMessage) {
+				t.Fatalf("expected descriptive error '%s', got %v", expectedMessage, deniedByGroup)
+			}
+		})
+		_, allowed := c.readResource("k8s://fake-context/v1/Namespace//default")
+		t.Run("ReadResource (not denied) returns resource", func(t *testing.T) {
+			if allowed != nil {
+				t.Fatalf("read resource should not fail %v", allowed)
+			}
+		})
+	})
+}
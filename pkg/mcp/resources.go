@@ -4,10 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/yaml"
 
 	"github.com/manusa/kubernetes-mcp-server/pkg/kubernetes"
 	"github.com/manusa/kubernetes-mcp-server/pkg/output"
@@ -35,6 +38,10 @@ func (s *Server) initResources() []server.ServerTool {
 				mcp.Description("Optional Namespace to retrieve the namespaced resources from (ignored in case of cluster scoped resources). If not provided, will list resources from all namespaces")),
 			mcp.WithString("labelSelector",
 				mcp.Description("Optional Kubernetes label selector (e.g. 'app=myapp,env=prod' or 'app in (myapp,yourapp)'), use this option when you want to filter the pods by label"), mcp.Pattern("([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]")),
+			mcp.WithString("fieldSelector",
+				mcp.Description("Optional Kubernetes field selector (e.g. 'metadata.name=my-resource' or 'status.phase=Running' for Pods), use this option when you want to filter the resources by field. Supported fields depend on the kind")),
+			withListLimit(),
+			withListContinue(),
 			// Tool annotations
 			mcp.WithTitleAnnotation("Resources: List"),
 			mcp.WithReadOnlyHintAnnotation(true),
@@ -69,6 +76,9 @@ func (s *Server) initResources() []server.ServerTool {
 				mcp.Description("A JSON or YAML containing a representation of the Kubernetes resource. Should include top-level fields such as apiVersion,kind,metadata, and spec"),
 				mcp.Required(),
 			),
+			mcp.WithBoolean("dry_run",
+				mcp.Description("If true, the request is validated and processed by the cluster (server-side dry-run) but the resource is not persisted (Optional, defaults to false)"),
+			),
 			// Tool annotations
 			mcp.WithTitleAnnotation("Resources: Create or Update"),
 			mcp.WithReadOnlyHintAnnotation(false),
@@ -76,6 +86,86 @@ func (s *Server) initResources() []server.ServerTool {
 			mcp.WithIdempotentHintAnnotation(true),
 			mcp.WithOpenWorldHintAnnotation(true),
 		), Handler: s.resourcesCreateOrUpdate},
+		{Tool: mcp.NewTool("resources_diff",
+			mcp.WithDescription("Preview the changes that creating or updating a Kubernetes resource would make in the current cluster by providing a YAML or JSON representation of the resource. "+
+				"Returns a unified diff between the live resource and the result of a server-side dry-run\n"+
+				commonApiVersion),
+			mcp.WithString("resource",
+				mcp.Description("A JSON or YAML containing a representation of the Kubernetes resource. Should include top-level fields such as apiVersion,kind,metadata, and spec"),
+				mcp.Required(),
+			),
+			// Tool annotations
+			mcp.WithTitleAnnotation("Resources: Diff"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithIdempotentHintAnnotation(true),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.resourcesDiff},
+		{Tool: mcp.NewTool("resources_patch",
+			mcp.WithDescription("Patch a Kubernetes resource in the current cluster by providing its apiVersion, kind, optionally the namespace, its name, and the patch to apply. "+
+				"Use this tool to change specific fields without providing the complete resource\n"+
+				commonApiVersion),
+			mcp.WithString("apiVersion",
+				mcp.Description("apiVersion of the resource (examples of valid apiVersion are: v1, apps/v1, networking.k8s.io/v1)"),
+				mcp.Required(),
+			),
+			mcp.WithString("kind",
+				mcp.Description("kind of the resource (examples of valid kind are: Pod, Service, Deployment, Ingress)"),
+				mcp.Required(),
+			),
+			mcp.WithString("namespace",
+				mcp.Description("Optional Namespace to patch the namespaced resource in (ignored in case of cluster scoped resources). If not provided, will patch resource in configured namespace"),
+			),
+			mcp.WithString("name", mcp.Description("Name of the resource"), mcp.Required()),
+			mcp.WithString("patch",
+				mcp.Description("A JSON or YAML containing the patch to apply. "+
+					"For json patches, a list of operations (e.g. [{\"op\": \"replace\", \"path\": \"/spec/replicas\", \"value\": 3}]). "+
+					"For merge and strategic patches, a partial representation of the resource (e.g. {\"spec\": {\"replicas\": 3}})"),
+				mcp.Required(),
+			),
+			mcp.WithString("patchType",
+				mcp.Description("Optional type of the patch: strategic (strategic merge patch, default), merge (JSON merge patch), or json (JSON patch). "+
+					"Strategic merge patches are applied as merge patches for custom resources"),
+				mcp.Enum("strategic", "merge", "json"),
+			),
+			// Tool annotations
+			mcp.WithTitleAnnotation("Resources: Patch"),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(true),
+			mcp.WithIdempotentHintAnnotation(false),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.resourcesPatch},
+		{Tool: mcp.NewTool("resources_scale",
+			mcp.WithDescription("Scale a Kubernetes resource in the current cluster through its scale subresource by providing its apiVersion, kind, optionally the namespace, its name, and the desired replicas. "+
+				"Works for any scalable kind (e.g. Deployment, StatefulSet, ReplicaSet, or custom resources declaring a scale subresource)\n"+
+				commonApiVersion),
+			mcp.WithString("apiVersion",
+				mcp.Description("apiVersion of the resource (examples of valid apiVersion are: apps/v1)"),
+				mcp.Required(),
+			),
+			mcp.WithString("kind",
+				mcp.Description("kind of the resource (examples of valid kind are: Deployment, StatefulSet, ReplicaSet)"),
+				mcp.Required(),
+			),
+			mcp.WithString("namespace",
+				mcp.Description("Optional Namespace to scale the namespaced resource in (ignored in case of cluster scoped resources). If not provided, will scale resource in configured namespace"),
+			),
+			mcp.WithString("name", mcp.Description("Name of the resource"), mcp.Required()),
+			mcp.WithNumber("replicas", mcp.Description("Desired number of replicas"), mcp.Min(0), mcp.Required()),
+			mcp.WithBoolean("wait",
+				mcp.Description("Optional, if true, waits until the ready replicas of the resource match the desired replicas, custom resources are checked against the status replicas of their scale subresource (default false)"),
+			),
+			mcp.WithNumber("timeout",
+				mcp.Description("Optional maximum number of seconds to wait for the resource to be ready when wait is true (default 60)"),
+				mcp.Min(1),
+			),
+			// Tool annotations
+			mcp.WithTitleAnnotation("Resources: Scale"),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(true),
+			mcp.WithIdempotentHintAnnotation(true),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.resourcesScale},
 		{Tool: mcp.NewTool("resources_delete",
 			mcp.WithDescription("Delete a Kubernetes resource in the current cluster by providing its apiVersion, kind, optionally the namespace, and its name\n"+
 				commonApiVersion),
@@ -118,6 +208,16 @@ func (s *Server) resourcesList(ctx context.Context, ctr mcp.CallToolRequest) (*m
 		}
 		resourceListOptions.ListOptions.LabelSelector = l
 	}
+	if fieldSelector := ctr.GetArguments()["fieldSelector"]; fieldSelector != nil {
+		f, ok := fieldSelector.(string)
+		if !ok {
+			return NewTextResult("", fmt.Errorf("fieldSelector is not a string")), nil
+		}
+		resourceListOptions.ListOptions.FieldSelector = f
+	}
+	if err := s.paginate(ctr.GetArguments(), &resourceListOptions); err != nil {
+		return NewTextResult("", fmt.Errorf("failed to list resources, %s", err)), nil
+	}
 	gvk, err := parseGroupVersionKind(ctr.GetArguments())
 	if err != nil {
 		return NewTextResult("", fmt.Errorf("failed to list resources, %s", err)), nil
@@ -132,7 +232,7 @@ func (s *Server) resourcesList(ctx context.Context, ctr mcp.CallToolRequest) (*m
 	if err != nil {
 		return NewTextResult("", fmt.Errorf("failed to list resources: %v", err)), nil
 	}
-	return NewTextResult(s.configuration.ListOutput.PrintObj(ret)), nil
+	return NewTextResult(s.printList(ret)), nil
 }
 
 func (s *Server) resourcesGet(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -176,8 +276,12 @@ func (s *Server) resourcesCreateOrUpdate(ctx context.Context, ctr mcp.CallToolRe
 	if !ok {
 		return NewTextResult("", fmt.Errorf("resource is not a string")), nil
 	}
+	dryRun := false
+	if v, ok := ctr.GetArguments()["dry_run"].(bool); ok {
+		dryRun = v
+	}
 
-	resources, err := s.k.Derived(ctx).ResourcesCreateOrUpdate(ctx, r)
+	resources, err := s.k.Derived(ctx).ResourcesCreateOrUpdate(ctx, r, dryRun)
 	if err != nil {
 		return NewTextResult("", fmt.Errorf("failed to create or update resources: %v", err)), nil
 	}
@@ -185,9 +289,169 @@ func (s *Server) resourcesCreateOrUpdate(ctx context.Context, ctr mcp.CallToolRe
 	if err != nil {
 		err = fmt.Errorf("failed to create or update resources:: %v", err)
 	}
+	if dryRun {
+		return NewTextResult("# The following resources (YAML) would be created or updated (dry-run, no changes were persisted)\n"+marshalledYaml, err), nil
+	}
 	return NewTextResult("# The following resources (YAML) have been created or updated successfully\n"+marshalledYaml, err), nil
 }
 
+func (s *Server) resourcesDiff(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	resource := ctr.GetArguments()["resource"]
+	if resource == nil || resource == "" {
+		return NewTextResult("", errors.New("failed to diff resources, missing argument resource")), nil
+	}
+
+	r, ok := resource.(string)
+	if !ok {
+		return NewTextResult("", fmt.Errorf("resource is not a string")), nil
+	}
+
+	diffs, err := s.k.Derived(ctx).ResourcesDiff(ctx, r)
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to diff resources: %v", err)), nil
+	}
+	ret := "# Differences between the live resources and the provided resources (server-side dry-run)\n"
+	for _, diff := range diffs {
+		id := fmt.Sprintf("%s/%s/%s/%s", diff.DryRun.GetAPIVersion(), diff.DryRun.GetKind(), diff.DryRun.GetNamespace(), diff.DryRun.GetName())
+		live, err := output.MarshalYamlForDiff(diff.Live)
+		if err != nil {
+			return NewTextResult("", fmt.Errorf("failed to diff resources: %v", err)), nil
+		}
+		dryRun, err := output.MarshalYamlForDiff(diff.DryRun)
+		if err != nil {
+			return NewTextResult("", fmt.Errorf("failed to diff resources: %v", err)), nil
+		}
+		unifiedDiff, err := output.UnifiedDiff("live/"+id, "dry-run/"+id, live, dryRun)
+		if err != nil {
+			return NewTextResult("", fmt.Errorf("failed to diff resources: %v", err)), nil
+		}
+		switch {
+		case diff.Live == nil:
+			ret += "## " + id + " (will be created)\n" + unifiedDiff
+		case unifiedDiff == "":
+			ret += "## " + id + " (no changes)\n"
+		default:
+			ret += "## " + id + " (will be updated)\n" + unifiedDiff
+		}
+	}
+	return NewTextResult(ret, nil), nil
+}
+
+func (s *Server) resourcesPatch(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	namespace := ctr.GetArguments()["namespace"]
+	if namespace == nil {
+		namespace = ""
+	}
+	gvk, err := parseGroupVersionKind(ctr.GetArguments())
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to patch resource, %s", err)), nil
+	}
+	name := ctr.GetArguments()["name"]
+	if name == nil {
+		return NewTextResult("", errors.New("failed to patch resource, missing argument name")), nil
+	}
+	patch := ctr.GetArguments()["patch"]
+	if patch == nil || patch == "" {
+		return NewTextResult("", errors.New("failed to patch resource, missing argument patch")), nil
+	}
+	patchType := ctr.GetArguments()["patchType"]
+	if patchType == nil {
+		patchType = "strategic"
+	}
+
+	ns, ok := namespace.(string)
+	if !ok {
+		return NewTextResult("", fmt.Errorf("namespace is not a string")), nil
+	}
+
+	n, ok := name.(string)
+	if !ok {
+		return NewTextResult("", fmt.Errorf("name is not a string")), nil
+	}
+
+	p, ok := patch.(string)
+	if !ok {
+		return NewTextResult("", fmt.Errorf("patch is not a string")), nil
+	}
+	jsonPatch, err := yaml.YAMLToJSON([]byte(p))
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to patch resource, invalid patch: %v", err)), nil
+	}
+
+	var pt types.PatchType
+	switch patchType {
+	case "strategic":
+		pt = types.StrategicMergePatchType
+	case "merge":
+		pt = types.MergePatchType
+	case "json":
+		pt = types.JSONPatchType
+	default:
+		return NewTextResult("", fmt.Errorf("failed to patch resource, invalid patchType %v (expected one of: strategic, merge, json)", patchType)), nil
+	}
+
+	ret, err := s.k.Derived(ctx).ResourcesPatch(ctx, gvk, ns, n, pt, jsonPatch)
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to patch resource: %v", err)), nil
+	}
+	marshalledYaml, err := output.MarshalYaml(ret)
+	if err != nil {
+		err = fmt.Errorf("failed to patch resource: %v", err)
+	}
+	return NewTextResult("# The following resource (YAML) has been patched successfully\n"+marshalledYaml, err), nil
+}
+
+func (s *Server) resourcesScale(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	namespace := ctr.GetArguments()["namespace"]
+	if namespace == nil {
+		namespace = ""
+	}
+	gvk, err := parseGroupVersionKind(ctr.GetArguments())
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to scale resource, %s", err)), nil
+	}
+	name := ctr.GetArguments()["name"]
+	if name == nil {
+		return NewTextResult("", errors.New("failed to scale resource, missing argument name")), nil
+	}
+	replicas := ctr.GetArguments()["replicas"]
+	if replicas == nil {
+		return NewTextResult("", errors.New("failed to scale resource, missing argument replicas")), nil
+	}
+	waitTimeout := time.Duration(0)
+	if w, ok := ctr.GetArguments()["wait"].(bool); ok && w {
+		waitTimeout = 60 * time.Second
+		if timeout, ok := ctr.GetArguments()["timeout"].(float64); ok && timeout >= 1 {
+			waitTimeout = time.Duration(timeout) * time.Second
+		}
+	}
+
+	ns, ok := namespace.(string)
+	if !ok {
+		return NewTextResult("", fmt.Errorf("namespace is not a string")), nil
+	}
+
+	n, ok := name.(string)
+	if !ok {
+		return NewTextResult("", fmt.Errorf("name is not a string")), nil
+	}
+
+	r, ok := replicas.(float64)
+	if !ok || r < 0 {
+		return NewTextResult("", fmt.Errorf("replicas is not a non-negative number")), nil
+	}
+
+	ret, err := s.k.Derived(ctx).ResourcesScale(ctx, gvk, ns, n, int64(r), waitTimeout)
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to scale resource: %v", err)), nil
+	}
+	result := fmt.Sprintf("%s %s scaled from %d to %d replicas", gvk.Kind, n, ret.PreviousReplicas, ret.Replicas)
+	if ret.ReadyReplicas != nil {
+		result += fmt.Sprintf(" (%d ready replicas)", *ret.ReadyReplicas)
+	}
+	return NewTextResult(result, nil), nil
+}
+
 func (s *Server) resourcesDelete(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	namespace := ctr.GetArguments()["namespace"]
 	if namespace == nil {
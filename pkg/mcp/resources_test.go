@@ -12,6 +12,7 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/dynamic"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/yaml"
 
 	"github.com/manusa/kubernetes-mcp-server/pkg/config"
@@ -57,6 +58,28 @@ func TestResourcesList(t *testing.T) {
 				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
 			}
 		})
+		t.Run("resources_list with unsupported fieldSelector returns clear error", func(t *testing.T) {
+			toolResult, _ := c.callTool("resources_list", map[string]interface{}{"apiVersion": "v1", "kind": "ConfigMap", "fieldSelector": "data.key=value"})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			if !strings.HasPrefix(toolResult.Content[0].(mcp.TextContent).Text, "failed to list resources: field selector data.key=value is not supported for ConfigMap, the field data.key can't be used to filter") {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("resources_list with fieldSelector returns filtered resources", func(t *testing.T) {
+			toolResult, _ := c.callTool("resources_list", map[string]interface{}{"apiVersion": "v1", "kind": "Namespace", "fieldSelector": "metadata.name=ns-1"})
+			if toolResult.IsError {
+				t.Fatalf("call tool failed %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+			var decoded []unstructured.Unstructured
+			if err := yaml.Unmarshal([]byte(toolResult.Content[0].(mcp.TextContent).Text), &decoded); err != nil {
+				t.Fatalf("invalid tool result content %v", err)
+			}
+			if len(decoded) != 1 || decoded[0].GetName() != "ns-1" {
+				t.Fatalf("invalid namespaces, expected only ns-1, got %v", decoded)
+			}
+		})
 		namespaces, err := c.callTool("resources_list", map[string]interface{}{"apiVersion": "v1", "kind": "Namespace"})
 		t.Run("resources_list returns namespaces", func(t *testing.T) {
 			if err != nil {
@@ -627,6 +650,361 @@ func TestResourcesCreateOrUpdateDenied(t *testing.T) {
 	})
 }
 
+func TestResourcesCreateOrUpdateDryRun(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		c.withEnvTest()
+		configMapYaml := "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: a-cm-dry-run\n  namespace: default\n"
+		toolResult, err := c.callTool("resources_create_or_update", map[string]interface{}{"resource": configMapYaml, "dry_run": true})
+		t.Run("resources_create_or_update with dry_run returns success", func(t *testing.T) {
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			if !strings.HasPrefix(toolResult.Content[0].(mcp.TextContent).Text, "# The following resources (YAML) would be created or updated (dry-run, no changes were persisted)") {
+				t.Fatalf("expected dry-run message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("resources_create_or_update with dry_run doesn't create ConfigMap", func(t *testing.T) {
+			_, err := c.newKubernetesClient().CoreV1().ConfigMaps("default").Get(c.ctx, "a-cm-dry-run", metav1.GetOptions{})
+			if err == nil {
+				t.Fatalf("ConfigMap should not exist")
+			}
+		})
+	})
+}
+
+func TestResourcesDiff(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		c.withEnvTest()
+		t.Run("resources_diff with nil resource returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("resources_diff", map[string]interface{}{})
+			if toolResult.IsError != true {
+				t.Fatalf("call tool should fail")
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "failed to diff resources, missing argument resource" {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		kc := c.newKubernetesClient()
+		_, _ = kc.CoreV1().ConfigMaps("default").Create(c.ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "a-cm-to-diff"},
+			Data:       map[string]string{"key": "live"},
+		}, metav1.CreateOptions{})
+		updated, err := c.callTool("resources_diff", map[string]interface{}{
+			"resource": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: a-cm-to-diff\n  namespace: default\ndata:\n  key: updated\n",
+		})
+		t.Run("resources_diff with existing resource returns diff", func(t *testing.T) {
+			if err != nil || updated.IsError {
+				t.Fatalf("call tool failed %v %v", err, updated.Content)
+			}
+			text := updated.Content[0].(mcp.TextContent).Text
+			if !strings.Contains(text, "## v1/ConfigMap/default/a-cm-to-diff (will be updated)") {
+				t.Fatalf("expected update header, got %v", text)
+			}
+			if !strings.Contains(text, "-  key: live\n") || !strings.Contains(text, "+  key: updated\n") {
+				t.Fatalf("expected data changes in diff, got %v", text)
+			}
+		})
+		t.Run("resources_diff with existing resource strips resourceVersion and managedFields", func(t *testing.T) {
+			text := updated.Content[0].(mcp.TextContent).Text
+			if strings.Contains(text, "resourceVersion") || strings.Contains(text, "managedFields") {
+				t.Fatalf("expected normalized diff, got %v", text)
+			}
+		})
+		t.Run("resources_diff doesn't update ConfigMap", func(t *testing.T) {
+			cm, _ := kc.CoreV1().ConfigMaps("default").Get(c.ctx, "a-cm-to-diff", metav1.GetOptions{})
+			if cm.Data["key"] != "live" {
+				t.Fatalf("ConfigMap should not be updated, got %v", cm.Data)
+			}
+		})
+		created, err := c.callTool("resources_diff", map[string]interface{}{
+			"resource": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: a-cm-to-diff-new\n  namespace: default\ndata:\n  key: new\n",
+		})
+		t.Run("resources_diff with new resource returns creation diff", func(t *testing.T) {
+			if err != nil || created.IsError {
+				t.Fatalf("call tool failed %v %v", err, created.Content)
+			}
+			text := created.Content[0].(mcp.TextContent).Text
+			if !strings.Contains(text, "## v1/ConfigMap/default/a-cm-to-diff-new (will be created)") || !strings.Contains(text, "+  key: new\n") {
+				t.Fatalf("expected creation diff, got %v", text)
+			}
+		})
+	})
+}
+
+func TestResourcesPatch(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		c.withEnvTest()
+		t.Run("resources_patch with nil name returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("resources_patch", map[string]interface{}{"apiVersion": "v1", "kind": "ConfigMap"})
+			if toolResult.IsError != true {
+				t.Fatalf("call tool should fail")
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "failed to patch resource, missing argument name" {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("resources_patch with nil patch returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("resources_patch", map[string]interface{}{"apiVersion": "v1", "kind": "ConfigMap", "name": "a-configmap-to-patch"})
+			if toolResult.IsError != true {
+				t.Fatalf("call tool should fail")
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "failed to patch resource, missing argument patch" {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("resources_patch with invalid patchType returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("resources_patch", map[string]interface{}{"apiVersion": "v1", "kind": "ConfigMap", "name": "a-configmap-to-patch", "patch": "{}", "patchType": "apply"})
+			if toolResult.IsError != true {
+				t.Fatalf("call tool should fail")
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "failed to patch resource, invalid patchType apply (expected one of: strategic, merge, json)" {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		kc := c.newKubernetesClient()
+		_, _ = kc.CoreV1().ConfigMaps("default").Create(c.ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "a-configmap-to-patch"},
+			Data:       map[string]string{"key": "value"},
+		}, metav1.CreateOptions{})
+		strategicPatch, err := c.callTool("resources_patch", map[string]interface{}{
+			"apiVersion": "v1", "kind": "ConfigMap", "name": "a-configmap-to-patch", "patch": "data:\n  strategic: patched\n",
+		})
+		t.Run("resources_patch with strategic (default) patch returns success", func(t *testing.T) {
+			if err != nil || strategicPatch.IsError {
+				t.Fatalf("call tool failed %v %v", err, strategicPatch.Content)
+			}
+			if !strings.HasPrefix(strategicPatch.Content[0].(mcp.TextContent).Text, "# The following resource (YAML) has been patched successfully") {
+				t.Fatalf("expected success message, got %v", strategicPatch.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		mergePatch, err := c.callTool("resources_patch", map[string]interface{}{
+			"apiVersion": "v1", "kind": "ConfigMap", "name": "a-configmap-to-patch", "patch": `{"data":{"merge":"patched"}}`, "patchType": "merge",
+		})
+		t.Run("resources_patch with merge patch returns success", func(t *testing.T) {
+			if err != nil || mergePatch.IsError {
+				t.Fatalf("call tool failed %v %v", err, mergePatch.Content)
+			}
+		})
+		jsonPatch, err := c.callTool("resources_patch", map[string]interface{}{
+			"apiVersion": "v1", "kind": "ConfigMap", "name": "a-configmap-to-patch", "patch": `[{"op":"replace","path":"/data/key","value":"patched"}]`, "patchType": "json",
+		})
+		t.Run("resources_patch with json patch returns success", func(t *testing.T) {
+			if err != nil || jsonPatch.IsError {
+				t.Fatalf("call tool failed %v %v", err, jsonPatch.Content)
+			}
+		})
+		t.Run("resources_patch patches ConfigMap", func(t *testing.T) {
+			cm, _ := kc.CoreV1().ConfigMaps("default").Get(c.ctx, "a-configmap-to-patch", metav1.GetOptions{})
+			if cm.Data["key"] != "patched" || cm.Data["strategic"] != "patched" || cm.Data["merge"] != "patched" {
+				t.Fatalf("invalid ConfigMap data, got %v", cm.Data)
+			}
+		})
+	})
+}
+
+func TestResourcesPatchCustomResource(t *testing.T) {
+	testCaseWithContext(t, &mcpContext{before: inOpenShift, after: inOpenShiftClear}, func(c *mcpContext) {
+		_, _ = c.callTool("resources_create_or_update", map[string]interface{}{
+			"resource": "apiVersion: route.openshift.io/v1\nkind: Route\nmetadata:\n  name: a-route-to-patch\n  namespace: default\nspec:\n  host: example.com\n",
+		})
+		strategicPatch, err := c.callTool("resources_patch", map[string]interface{}{
+			"apiVersion": "route.openshift.io/v1", "kind": "Route", "name": "a-route-to-patch", "patch": `{"spec":{"host":"patched.example.com"}}`,
+		})
+		t.Run("resources_patch with strategic patch falls back to merge patch for custom resources", func(t *testing.T) {
+			if err != nil || strategicPatch.IsError {
+				t.Fatalf("call tool failed %v %v", err, strategicPatch.Content)
+			}
+			if !strings.Contains(strategicPatch.Content[0].(mcp.TextContent).Text, "host: patched.example.com") {
+				t.Fatalf("invalid patched resource, got %v", strategicPatch.Content[0].(mcp.TextContent).Text)
+			}
+		})
+	})
+}
+
+func TestResourcesPatchDenied(t *testing.T) {
+	deniedResourcesServer := &config.StaticConfig{
+		DeniedResources: []config.GroupVersionKind{
+			{Version: "v1", Kind: "Secret"},
+			{Group: "rbac.authorization.k8s.io", Version: "v1"},
+		},
+	}
+	testCaseWithContext(t, &mcpContext{staticConfig: deniedResourcesServer}, func(c *mcpContext) {
+		c.withEnvTest()
+		deniedByKind, _ := c.callTool("resources_patch", map[string]interface{}{"apiVersion": "v1", "kind": "Secret", "namespace": "default", "name": "denied-secret", "patch": "{}"})
+		t.Run("resources_patch (denied by kind) has error", func(t *testing.T) {
+			if !deniedByKind.IsError {
+				t.Fatalf("call tool should fail")
+			}
+		})
+		t.Run("resources_patch (denied by kind) describes denial", func(t *testing.T) {
+			expectedMessage := "failed to patch resource: resource not allowed: /v1, Kind=Secret"
+			if deniedByKind.Content[0].(mcp.TextContent).Text != expectedMessage {
+				t.Fatalf("expected descriptive error '%s', got %v", expectedMessage, deniedByKind.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		deniedByGroup, _ := c.callTool("resources_patch", map[string]interface{}{"apiVersion": "rbac.authorization.k8s.io/v1", "kind": "Role", "namespace": "default", "name": "denied-role", "patch": "{}"})
+		t.Run("resources_patch (denied by group) has error", func(t *testing.T) {
+			if !deniedByGroup.IsError {
+				t.Fatalf("call tool should fail")
+			}
+		})
+		t.Run("resources_patch (denied by group) describes denial", func(t *testing.T) {
+			expectedMessage := "failed to patch resource: resource not allowed: rbac.authorization.k8s.io/v1, Kind=Role"
+			if deniedByGroup.Content[0].(mcp.TextContent).Text != expectedMessage {
+				t.Fatalf("expected descriptive error '%s', got %v", expectedMessage, deniedByGroup.Content[0].(mcp.TextContent).Text)
+			}
+		})
+	})
+}
+
+func TestResourcesScale(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		c.withEnvTest()
+		client := c.newKubernetesClient()
+		deployment := rolloutDeployment("a-deployment-to-scale", "nginx")
+		deployment.Spec.Replicas = ptr.To(int32(1))
+		_, _ = client.AppsV1().Deployments("default").Create(c.ctx, deployment, metav1.CreateOptions{})
+		t.Run("resources_scale with missing replicas returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("resources_scale", map[string]interface{}{"apiVersion": "apps/v1", "kind": "Deployment", "name": "a-deployment-to-scale"})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "failed to scale resource, missing argument replicas" {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("resources_scale with not scalable kind returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("resources_scale", map[string]interface{}{"apiVersion": "v1", "kind": "Namespace", "name": "ns-1", "replicas": 1})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "failed to scale resource: Namespace ns-1 doesn't support the scale subresource" {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("resources_scale scales the resource", func(t *testing.T) {
+			toolResult, err := c.callTool("resources_scale", map[string]interface{}{"apiVersion": "apps/v1", "kind": "Deployment", "name": "a-deployment-to-scale", "replicas": 3})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "Deployment a-deployment-to-scale scaled from 1 to 3 replicas" {
+				t.Fatalf("unexpected result %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+			scaled, _ := client.AppsV1().Deployments("default").Get(c.ctx, "a-deployment-to-scale", metav1.GetOptions{})
+			if *scaled.Spec.Replicas != 3 {
+				t.Fatalf("expected 3 replicas, got %v", *scaled.Spec.Replicas)
+			}
+		})
+		t.Run("resources_scale with wait times out if replicas are not ready", func(t *testing.T) {
+			toolResult, _ := c.callTool("resources_scale", map[string]interface{}{
+				"apiVersion": "apps/v1", "kind": "Deployment", "name": "a-deployment-to-scale", "replicas": 2, "wait": true, "timeout": 1,
+			})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			if !strings.HasPrefix(toolResult.Content[0].(mcp.TextContent).Text, "failed to scale resource: Deployment a-deployment-to-scale was scaled to 2 replicas, but only 0 replicas were ready after waiting 1s") {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("resources_scale with wait returns ready replicas", func(t *testing.T) {
+			toolResult, err := c.callTool("resources_scale", map[string]interface{}{
+				"apiVersion": "apps/v1", "kind": "Deployment", "name": "a-deployment-to-scale", "replicas": 0, "wait": true,
+			})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "Deployment a-deployment-to-scale scaled from 2 to 0 replicas (0 ready replicas)" {
+				t.Fatalf("unexpected result %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+	})
+}
+
+func TestResourcesScaleCustomResource(t *testing.T) {
+	scalableCrd := func(c *mcpContext) {
+		if err := c.crdApply(`{
+          "apiVersion": "apiextensions.k8s.io/v1",
+          "kind": "CustomResourceDefinition",
+          "metadata": {"name": "scalables.example.com"},
+          "spec": {
+            "group": "example.com",
+            "versions": [{
+              "name": "v1","served": true,"storage": true,
+              "schema": {"openAPIV3Schema": {"type": "object","x-kubernetes-preserve-unknown-fields": true}},
+              "subresources": {"scale": {"specReplicasPath": ".spec.replicas", "statusReplicasPath": ".status.replicas"}}
+            }],
+            "scope": "Namespaced",
+            "names": {"plural": "scalables","singular": "scalable","kind": "Scalable"}
+          }
+        }`); err != nil {
+			panic(err)
+		}
+	}
+	scalableCrdClear := func(c *mcpContext) {
+		if err := c.crdDelete("scalables.example.com"); err != nil {
+			panic(err)
+		}
+	}
+	testCaseWithContext(t, &mcpContext{before: scalableCrd, after: scalableCrdClear}, func(c *mcpContext) {
+		_, _ = c.callTool("resources_create_or_update", map[string]interface{}{
+			"resource": "apiVersion: example.com/v1\nkind: Scalable\nmetadata:\n  name: a-scalable\n  namespace: default\nspec:\n  replicas: 1\n",
+		})
+		toolResult, err := c.callTool("resources_scale", map[string]interface{}{"apiVersion": "example.com/v1", "kind": "Scalable", "name": "a-scalable", "replicas": 5})
+		t.Run("resources_scale scales custom resources with a scale subresource", func(t *testing.T) {
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "Scalable a-scalable scaled from 1 to 5 replicas" {
+				t.Fatalf("unexpected result %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("resources_scale with wait times out if scale subresource status replicas don't match", func(t *testing.T) {
+			toolResult, _ := c.callTool("resources_scale", map[string]interface{}{
+				"apiVersion": "example.com/v1", "kind": "Scalable", "name": "a-scalable", "replicas": 2, "wait": true, "timeout": 1,
+			})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			if !strings.HasPrefix(toolResult.Content[0].(mcp.TextContent).Text, "failed to scale resource: Scalable a-scalable was scaled to 2 replicas, but its scale subresource reported 0 replicas after waiting 1s") {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("resources_scale with wait returns scale subresource status replicas", func(t *testing.T) {
+			_, _ = c.callTool("resources_create_or_update", map[string]interface{}{
+				"resource": "apiVersion: example.com/v1\nkind: Scalable\nmetadata:\n  name: a-scalable\n  namespace: default\nspec:\n  replicas: 2\nstatus:\n  replicas: 3\n",
+			})
+			toolResult, err := c.callTool("resources_scale", map[string]interface{}{
+				"apiVersion": "example.com/v1", "kind": "Scalable", "name": "a-scalable", "replicas": 3, "wait": true,
+			})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "Scalable a-scalable scaled from 2 to 3 replicas (3 ready replicas)" {
+				t.Fatalf("unexpected result %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+	})
+}
+
+func TestResourcesScaleDenied(t *testing.T) {
+	deniedResourcesServer := &config.StaticConfig{DeniedResources: []config.GroupVersionKind{{Group: "apps", Version: "v1", Kind: "Deployment"}}}
+	testCaseWithContext(t, &mcpContext{staticConfig: deniedResourcesServer}, func(c *mcpContext) {
+		c.withEnvTest()
+		scaleDenied, _ := c.callTool("resources_scale", map[string]interface{}{"apiVersion": "apps/v1", "kind": "Deployment", "name": "a-deployment", "replicas": 1})
+		t.Run("resources_scale has error", func(t *testing.T) {
+			if !scaleDenied.IsError {
+				t.Fatalf("call tool should fail")
+			}
+		})
+		t.Run("resources_scale describes denial", func(t *testing.T) {
+			expectedMessage := "failed to scale resource: resource not allowed: apps/v1, Kind=Deployment"
+			if scaleDenied.Content[0].(mcp.TextContent).Text != expectedMessage {
+				t.Fatalf("expected descriptive error '%s', got %v", expectedMessage, scaleDenied.Content[0].(mcp.TextContent).Text)
+			}
+		})
+	})
+}
+
 func TestResourcesDelete(t *testing.T) {
 	testCase(t, func(c *mcpContext) {
 		c.withEnvTest()
@@ -0,0 +1,215 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/manusa/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/manusa/kubernetes-mcp-server/pkg/output"
+)
+
+func (s *Server) initRollout() []server.ServerTool {
+	return []server.ServerTool{
+		{Tool: mcp.NewTool("rollout_status",
+			mcp.WithDescription("Get the rollout status of a Deployment, StatefulSet, or DaemonSet in the current or provided namespace, "+
+				"including its progress conditions and, for Deployments, the replica counts of the new and old ReplicaSets"),
+			withRolloutKind(),
+			mcp.WithString("name", mcp.Description("Name of the workload"), mcp.Required()),
+			mcp.WithString("namespace", mcp.Description("Namespace of the workload (Optional, current namespace if not provided)")),
+			mcp.WithNumber("revision", mcp.Description("Revision to check the status against (Optional, latest revision if not provided)"), mcp.Min(1)),
+			// Tool annotations
+			mcp.WithTitleAnnotation("Rollout: Status"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.rolloutStatus},
+		{Tool: mcp.NewTool("rollout_history",
+			mcp.WithDescription("Get the rollout history (revisions) of a Deployment, StatefulSet, or DaemonSet in the current or provided namespace"),
+			withRolloutKind(),
+			mcp.WithString("name", mcp.Description("Name of the workload"), mcp.Required()),
+			mcp.WithString("namespace", mcp.Description("Namespace of the workload (Optional, current namespace if not provided)")),
+			mcp.WithNumber("revision", mcp.Description("Revision to show the pod template details of (Optional, all revisions are listed if not provided)"), mcp.Min(1)),
+			// Tool annotations
+			mcp.WithTitleAnnotation("Rollout: History"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.rolloutHistory},
+		{Tool: mcp.NewTool("rollout_restart",
+			mcp.WithDescription("Restart a Deployment, StatefulSet, or DaemonSet in the current or provided namespace (pods are replaced following the workload update strategy)"),
+			withRolloutKind(),
+			mcp.WithString("name", mcp.Description("Name of the workload"), mcp.Required()),
+			mcp.WithString("namespace", mcp.Description("Namespace of the workload (Optional, current namespace if not provided)")),
+			// Tool annotations
+			mcp.WithTitleAnnotation("Rollout: Restart"),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(true),
+			mcp.WithIdempotentHintAnnotation(false),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.rolloutRestart},
+		{Tool: mcp.NewTool("rollout_undo",
+			mcp.WithDescription("Roll back a Deployment, StatefulSet, or DaemonSet in the current or provided namespace to a previous revision"),
+			withRolloutKind(),
+			mcp.WithString("name", mcp.Description("Name of the workload"), mcp.Required()),
+			mcp.WithString("namespace", mcp.Description("Namespace of the workload (Optional, current namespace if not provided)")),
+			mcp.WithNumber("toRevision", mcp.Description("Revision to roll back to (Optional, previous revision if not provided)"), mcp.Min(1)),
+			// Tool annotations
+			mcp.WithTitleAnnotation("Rollout: Undo"),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(true),
+			mcp.WithIdempotentHintAnnotation(false),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.rolloutUndo},
+		{Tool: mcp.NewTool("rollout_pause",
+			mcp.WithDescription("Pause the rollout of a Deployment in the current or provided namespace, changes to the paused Deployment are not rolled out until it's resumed"),
+			withRolloutKind(),
+			mcp.WithString("name", mcp.Description("Name of the workload"), mcp.Required()),
+			mcp.WithString("namespace", mcp.Description("Namespace of the workload (Optional, current namespace if not provided)")),
+			// Tool annotations
+			mcp.WithTitleAnnotation("Rollout: Pause"),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithIdempotentHintAnnotation(false),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.rolloutPause},
+		{Tool: mcp.NewTool("rollout_resume",
+			mcp.WithDescription("Resume the paused rollout of a Deployment in the current or provided namespace"),
+			withRolloutKind(),
+			mcp.WithString("name", mcp.Description("Name of the workload"), mcp.Required()),
+			mcp.WithString("namespace", mcp.Description("Namespace of the workload (Optional, current namespace if not provided)")),
+			// Tool annotations
+			mcp.WithTitleAnnotation("Rollout: Resume"),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithIdempotentHintAnnotation(false),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.rolloutResume},
+	}
+}
+
+func withRolloutKind() mcp.ToolOption {
+	return mcp.WithString("kind",
+		mcp.Description("Kind of the workload (apps/v1)"),
+		mcp.Enum(kubernetes.RolloutKinds...),
+		mcp.Required(),
+	)
+}
+
+// rolloutArguments returns the workload GroupVersionKind, namespace, and name from the tool arguments
+func rolloutArguments(ctr mcp.CallToolRequest) (*schema.GroupVersionKind, string, string, error) {
+	kind, ok := ctr.GetArguments()["kind"].(string)
+	if !ok || kind == "" {
+		return nil, "", "", fmt.Errorf("missing argument kind")
+	}
+	name, ok := ctr.GetArguments()["name"].(string)
+	if !ok || name == "" {
+		return nil, "", "", fmt.Errorf("missing argument name")
+	}
+	namespace := ""
+	if v, ok := ctr.GetArguments()["namespace"].(string); ok {
+		namespace = v
+	}
+	return &schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: kind}, namespace, name, nil
+}
+
+// rolloutRevision returns the revision from the provided tool argument, 0 if not provided
+func rolloutRevision(ctr mcp.CallToolRequest, argument string) (int64, error) {
+	revision := ctr.GetArguments()[argument]
+	if revision == nil {
+		return 0, nil
+	}
+	r, ok := revision.(float64)
+	if !ok || r < 1 {
+		return 0, fmt.Errorf("%s is not a positive number", argument)
+	}
+	return int64(r), nil
+}
+
+func (s *Server) rolloutStatus(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	gvk, namespace, name, err := rolloutArguments(ctr)
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to get rollout status, %s", err)), nil
+	}
+	revision, err := rolloutRevision(ctr, "revision")
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to get rollout status, %s", err)), nil
+	}
+	ret, err := s.k.Derived(ctx).RolloutStatus(ctx, gvk, namespace, name, revision)
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to get rollout status for %s %s: %v", gvk.Kind, name, err)), nil
+	}
+	marshalledYaml, err := output.MarshalYaml(ret)
+	if err != nil {
+		err = fmt.Errorf("failed to get rollout status for %s %s: %v", gvk.Kind, name, err)
+	}
+	return NewTextResult("# The rollout status of the "+gvk.Kind+" (YAML) is:\n"+marshalledYaml, err), nil
+}
+
+func (s *Server) rolloutHistory(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	gvk, namespace, name, err := rolloutArguments(ctr)
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to get rollout history, %s", err)), nil
+	}
+	revision, err := rolloutRevision(ctr, "revision")
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to get rollout history, %s", err)), nil
+	}
+	ret, err := s.k.Derived(ctx).RolloutHistory(ctx, gvk, namespace, name, revision)
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to get rollout history for %s %s: %v", gvk.Kind, name, err)), nil
+	}
+	return NewTextResult(ret, nil), nil
+}
+
+func (s *Server) rolloutRestart(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	gvk, namespace, name, err := rolloutArguments(ctr)
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to restart rollout, %s", err)), nil
+	}
+	if _, err = s.k.Derived(ctx).RolloutRestart(ctx, gvk, namespace, name); err != nil {
+		return NewTextResult("", fmt.Errorf("failed to restart rollout for %s %s: %v", gvk.Kind, name, err)), nil
+	}
+	return NewTextResult(fmt.Sprintf("%s %s restarted", gvk.Kind, name), nil), nil
+}
+
+func (s *Server) rolloutUndo(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	gvk, namespace, name, err := rolloutArguments(ctr)
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to undo rollout, %s", err)), nil
+	}
+	toRevision, err := rolloutRevision(ctr, "toRevision")
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to undo rollout, %s", err)), nil
+	}
+	ret, err := s.k.Derived(ctx).RolloutUndo(ctx, gvk, namespace, name, toRevision)
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to undo rollout for %s %s: %v", gvk.Kind, name, err)), nil
+	}
+	return NewTextResult(fmt.Sprintf("%s %s %s", gvk.Kind, name, ret), nil), nil
+}
+
+func (s *Server) rolloutPause(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	gvk, namespace, name, err := rolloutArguments(ctr)
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to pause rollout, %s", err)), nil
+	}
+	if _, err = s.k.Derived(ctx).RolloutPause(ctx, gvk, namespace, name); err != nil {
+		return NewTextResult("", fmt.Errorf("failed to pause rollout for %s %s: %v", gvk.Kind, name, err)), nil
+	}
+	return NewTextResult(fmt.Sprintf("%s %s paused", gvk.Kind, name), nil), nil
+}
+
+func (s *Server) rolloutResume(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	gvk, namespace, name, err := rolloutArguments(ctr)
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to resume rollout, %s", err)), nil
+	}
+	if _, err = s.k.Derived(ctx).RolloutResume(ctx, gvk, namespace, name); err != nil {
+		return NewTextResult("", fmt.Errorf("failed to resume rollout for %s %s: %v", gvk.Kind, name, err)), nil
+	}
+	return NewTextResult(fmt.Sprintf("%s %s resumed", gvk.Kind, name), nil), nil
+}
@@ -0,0 +1,244 @@
+package mcp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/yaml"
+
+	"github.com/manusa/kubernetes-mcp-server/pkg/config"
+	"github.com/manusa/kubernetes-mcp-server/pkg/kubernetes"
+)
+
+func rolloutDeployment(name, image string) *appsv1.Deployment {
+	labels := map[string]string{"app": name}
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "nginx", Image: image}}},
+			},
+		},
+	}
+}
+
+// rolloutReplicaSet creates a ReplicaSet owned by the provided Deployment with the given revision (there's no deployment controller in envtest)
+func rolloutReplicaSet(c *mcpContext, deployment *appsv1.Deployment, revision, image string, replicas int32) {
+	template := deployment.Spec.Template.DeepCopy()
+	template.Spec.Containers[0].Image = image
+	_, _ = c.newKubernetesClient().AppsV1().ReplicaSets(deployment.Namespace).Create(c.ctx, &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        deployment.Name + "-" + revision,
+			Namespace:   deployment.Namespace,
+			Labels:      deployment.Spec.Template.Labels,
+			Annotations: map[string]string{"deployment.kubernetes.io/revision": revision},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: "apps/v1", Kind: "Deployment", Name: deployment.Name, UID: deployment.UID, Controller: ptr.To(true),
+			}},
+		},
+		Spec: appsv1.ReplicaSetSpec{
+			Replicas: ptr.To(replicas),
+			Selector: deployment.Spec.Selector,
+			Template: *template,
+		},
+	}, metav1.CreateOptions{})
+}
+
+func TestRolloutStatus(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		c.withEnvTest()
+		t.Run("rollout_status with missing kind returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("rollout_status", map[string]interface{}{"name": "a-deployment"})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "failed to get rollout status, missing argument kind" {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("rollout_status with unsupported kind returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("rollout_status", map[string]interface{}{"kind": "ReplicaSet", "name": "a-replicaset"})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "failed to get rollout status for ReplicaSet a-replicaset: "+
+				"rollout is not supported for apps/v1, Kind=ReplicaSet, supported kinds are: Deployment, StatefulSet, DaemonSet" {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		deployment, _ := c.newKubernetesClient().AppsV1().Deployments("default").
+			Create(c.ctx, rolloutDeployment("a-deployment", "nginx:2"), metav1.CreateOptions{})
+		rolloutReplicaSet(c, deployment, "1", "nginx:1", 1)
+		rolloutReplicaSet(c, deployment, "2", "nginx:2", 0)
+		toolResult, err := c.callTool("rollout_status", map[string]interface{}{"kind": "Deployment", "name": "a-deployment"})
+		t.Run("rollout_status returns status", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("call tool failed %v", err)
+			}
+			if toolResult.IsError {
+				t.Fatalf("call tool failed %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		var status kubernetes.RolloutStatus
+		err = yaml.Unmarshal([]byte(strings.TrimPrefix(toolResult.Content[0].(mcp.TextContent).Text, "# The rollout status of the Deployment (YAML) is:\n")), &status)
+		t.Run("rollout_status has yaml content", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("invalid tool result content %v", err)
+			}
+		})
+		t.Run("rollout_status reports the rollout is not done", func(t *testing.T) {
+			if status.Done {
+				t.Fatalf("expected rollout not to be done")
+			}
+			if status.Message != "Waiting for deployment spec update to be observed..." {
+				t.Fatalf("unexpected message %v", status.Message)
+			}
+		})
+		t.Run("rollout_status reports the new ReplicaSet", func(t *testing.T) {
+			if status.NewReplicaSet == nil || status.NewReplicaSet.Name != "a-deployment-2" || status.NewReplicaSet.Revision != 2 {
+				t.Fatalf("unexpected new ReplicaSet %v", status.NewReplicaSet)
+			}
+		})
+		t.Run("rollout_status reports the old ReplicaSets", func(t *testing.T) {
+			if len(status.OldReplicaSets) != 1 || status.OldReplicaSets[0].Name != "a-deployment-1" || status.OldReplicaSets[0].Revision != 1 {
+				t.Fatalf("unexpected old ReplicaSets %v", status.OldReplicaSets)
+			}
+		})
+	})
+}
+
+func TestRolloutHistoryAndUndo(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		c.withEnvTest()
+		client := c.newKubernetesClient()
+		deployment, _ := client.AppsV1().Deployments("default").
+			Create(c.ctx, rolloutDeployment("a-deployment", "nginx:2"), metav1.CreateOptions{})
+		rolloutReplicaSet(c, deployment, "1", "nginx:1", 0)
+		rolloutReplicaSet(c, deployment, "2", "nginx:2", 1)
+		t.Run("rollout_history lists revisions", func(t *testing.T) {
+			toolResult, err := c.callTool("rollout_history", map[string]interface{}{"kind": "Deployment", "name": "a-deployment"})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "REVISION  CHANGE-CAUSE\n1         <none>\n2         <none>\n" {
+				t.Fatalf("unexpected history %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("rollout_history with revision shows the pod template", func(t *testing.T) {
+			toolResult, err := c.callTool("rollout_history", map[string]interface{}{"kind": "Deployment", "name": "a-deployment", "revision": 1})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			if !strings.Contains(toolResult.Content[0].(mcp.TextContent).Text, "nginx:1") {
+				t.Fatalf("unexpected revision details %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("rollout_undo with nonexistent revision returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("rollout_undo", map[string]interface{}{"kind": "Deployment", "name": "a-deployment", "toRevision": 5})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "failed to undo rollout for Deployment a-deployment: unable to find specified revision 5 in history" {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("rollout_undo rolls back to revision", func(t *testing.T) {
+			toolResult, err := c.callTool("rollout_undo", map[string]interface{}{"kind": "Deployment", "name": "a-deployment", "toRevision": 1})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "Deployment a-deployment rolled back" {
+				t.Fatalf("unexpected result %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+			rolledBack, _ := client.AppsV1().Deployments("default").Get(c.ctx, "a-deployment", metav1.GetOptions{})
+			if rolledBack.Spec.Template.Spec.Containers[0].Image != "nginx:1" {
+				t.Fatalf("expected image nginx:1, got %v", rolledBack.Spec.Template.Spec.Containers[0].Image)
+			}
+		})
+	})
+}
+
+func TestRolloutRestartPauseResume(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		c.withEnvTest()
+		client := c.newKubernetesClient()
+		_, _ = client.AppsV1().Deployments("default").
+			Create(c.ctx, rolloutDeployment("a-deployment", "nginx"), metav1.CreateOptions{})
+		t.Run("rollout_restart annotates the pod template", func(t *testing.T) {
+			toolResult, err := c.callTool("rollout_restart", map[string]interface{}{"kind": "Deployment", "name": "a-deployment"})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "Deployment a-deployment restarted" {
+				t.Fatalf("unexpected result %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+			restarted, _ := client.AppsV1().Deployments("default").Get(c.ctx, "a-deployment", metav1.GetOptions{})
+			if restarted.Spec.Template.Annotations["kubectl.kubernetes.io/restartedAt"] == "" {
+				t.Fatalf("expected restartedAt annotation, got %v", restarted.Spec.Template.Annotations)
+			}
+		})
+		t.Run("rollout_pause pauses the deployment", func(t *testing.T) {
+			toolResult, err := c.callTool("rollout_pause", map[string]interface{}{"kind": "Deployment", "name": "a-deployment"})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			paused, _ := client.AppsV1().Deployments("default").Get(c.ctx, "a-deployment", metav1.GetOptions{})
+			if !paused.Spec.Paused {
+				t.Fatalf("expected deployment to be paused")
+			}
+		})
+		t.Run("rollout_pause on paused deployment returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("rollout_pause", map[string]interface{}{"kind": "Deployment", "name": "a-deployment"})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "failed to pause rollout for Deployment a-deployment: Deployment default/a-deployment is already paused" {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("rollout_restart on paused deployment returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("rollout_restart", map[string]interface{}{"kind": "Deployment", "name": "a-deployment"})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+		})
+		t.Run("rollout_resume resumes the deployment", func(t *testing.T) {
+			toolResult, err := c.callTool("rollout_resume", map[string]interface{}{"kind": "Deployment", "name": "a-deployment"})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			resumed, _ := client.AppsV1().Deployments("default").Get(c.ctx, "a-deployment", metav1.GetOptions{})
+			if resumed.Spec.Paused {
+				t.Fatalf("expected deployment to be resumed")
+			}
+		})
+	})
+}
+
+func TestRolloutDenied(t *testing.T) {
+	deniedResourcesServer := &config.StaticConfig{DeniedResources: []config.GroupVersionKind{{Group: "apps", Version: "v1", Kind: "ReplicaSet"}}}
+	testCaseWithContext(t, &mcpContext{staticConfig: deniedResourcesServer}, func(c *mcpContext) {
+		c.withEnvTest()
+		_, _ = c.newKubernetesClient().AppsV1().Deployments("default").
+			Create(c.ctx, rolloutDeployment("a-deployment", "nginx"), metav1.CreateOptions{})
+		rolloutHistory, _ := c.callTool("rollout_history", map[string]interface{}{"kind": "Deployment", "name": "a-deployment"})
+		t.Run("rollout_history has error", func(t *testing.T) {
+			if !rolloutHistory.IsError {
+				t.Fatalf("call tool should fail")
+			}
+		})
+		t.Run("rollout_history describes denial", func(t *testing.T) {
+			expectedMessage := "failed to get rollout history for Deployment a-deployment: resource not allowed: apps/v1, Kind=ReplicaSet"
+			if rolloutHistory.Content[0].(mcp.TextContent).Text != expectedMessage {
+				t.Fatalf("expected descriptive error '%s', got %v", expectedMessage, rolloutHistory.Content[0].(mcp.TextContent).Text)
+			}
+		})
+	})
+}
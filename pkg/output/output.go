@@ -2,6 +2,9 @@ package output
 
 import (
 	"bytes"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -119,6 +122,37 @@ func MarshalYaml(v any) (string, error) {
 	return string(ret), nil
 }
 
+// MarshalYamlForDiff marshals the object as MarshalYaml does, additionally stripping the fields that change on every write (resourceVersion).
+// A nil object is marshalled as an empty string.
+func MarshalYamlForDiff(obj *unstructured.Unstructured) (string, error) {
+	if obj == nil {
+		return "", nil
+	}
+	obj = obj.DeepCopy()
+	obj.SetResourceVersion("")
+	return MarshalYaml(obj)
+}
+
+// UnifiedDiff returns the unified diff between the from and to texts, or an empty string if they are equal.
+func UnifiedDiff(fromName, toName, from, to string) (string, error) {
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitLines(from),
+		B:        splitLines(to),
+		FromFile: fromName,
+		ToFile:   toName,
+		Context:  3,
+	})
+}
+
+// splitLines splits the text into lines keeping the line endings (unlike difflib.SplitLines, no extra empty line is added)
+func splitLines(text string) []string {
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
 func init() {
 	Names = make([]string, 0)
 	for _, output := range Outputs {
@@ -30,3 +30,54 @@ func TestPlainTextUnstructuredList(t *testing.T) {
 		}
 	})
 }
+
+func TestMarshalYamlForDiff(t *testing.T) {
+	obj := &unstructured.Unstructured{}
+	_ = json.Unmarshal([]byte(`{
+		"apiVersion": "v1", "kind": "ConfigMap",
+		"metadata": {"name": "cm", "resourceVersion": "1337", "managedFields": [{"manager": "kubectl"}]},
+		"data": {"key": "value"}
+	}`), obj)
+	out, err := MarshalYamlForDiff(obj)
+	t.Run("marshals the object", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("Error marshalling object: %v", err)
+		}
+		if !regexp.MustCompile(`key: value`).MatchString(out) {
+			t.Errorf("Expected data in output: %s", out)
+		}
+	})
+	t.Run("strips resourceVersion and managedFields", func(t *testing.T) {
+		if m, _ := regexp.MatchString("resourceVersion|managedFields", out); m {
+			t.Errorf("Expected normalized output: %s", out)
+		}
+	})
+	t.Run("doesn't modify the original object", func(t *testing.T) {
+		if obj.GetResourceVersion() != "1337" {
+			t.Errorf("Expected original resourceVersion, got %s", obj.GetResourceVersion())
+		}
+	})
+	t.Run("marshals nil as empty", func(t *testing.T) {
+		if out, _ := MarshalYamlForDiff(nil); out != "" {
+			t.Errorf("Expected empty output, got %s", out)
+		}
+	})
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	t.Run("returns unified diff", func(t *testing.T) {
+		out, err := UnifiedDiff("a", "b", "key: 1\nother: 1\n", "key: 2\nother: 1\n")
+		if err != nil {
+			t.Fatalf("Error computing diff: %v", err)
+		}
+		expected := "--- a\n+++ b\n@@ -1,2 +1,2 @@\n-key: 1\n+key: 2\n other: 1\n"
+		if out != expected {
+			t.Errorf("Expected diff:\n%s\ngot:\n%s", expected, out)
+		}
+	})
+	t.Run("returns empty string for equal texts", func(t *testing.T) {
+		if out, _ := UnifiedDiff("a", "b", "key: 1\n", "key: 1\n"); out != "" {
+			t.Errorf("Expected empty diff, got %s", out)
+		}
+	})
+}